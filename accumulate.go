@@ -0,0 +1,26 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "reflect"
+
+// accumulate adds a function to the pending functions, unless it's already
+// one of them, see WithAccumulateFuncs.
+// d.mu must be held.
+func (d *Debouncer) accumulate(f func(), fi func(info FireInfo)) {
+	var key uintptr
+	if fi != nil {
+		key = reflect.ValueOf(fi).Pointer()
+	} else {
+		key = reflect.ValueOf(f).Pointer()
+	}
+	if d.accumulated[key] {
+		return
+	}
+	if d.accumulated == nil {
+		d.accumulated = make(map[uintptr]bool)
+	}
+	d.accumulated[key] = true
+	d.push(f, fi)
+}
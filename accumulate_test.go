@@ -0,0 +1,39 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestAccumulateFuncs(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []string
+
+	refreshA := func() { executed = append(executed, "a") }
+	refreshB := func() { executed = append(executed, "b") }
+	refreshC := func() { executed = append(executed, "c") }
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock), debounce.WithAccumulateFuncs(true))
+
+	for _, f := range []func(){refreshB, refreshA, refreshB, refreshC, refreshA} {
+		d.Do(f)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	// The next burst starts over.
+	d.Do(refreshA)
+	clock.Advance(100 * time.Millisecond)
+
+	expected := []string{"b", "a", "c", "a"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+}
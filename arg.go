@@ -0,0 +1,113 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"reflect"
+	"time"
+)
+
+// NewArg returns a debounced function that takes a value. When the debounced
+// function stops being called for the given duration, handler is called with
+// the latest value; the last one wins.
+//
+// The value is captured when the execution is triggered, so an execution
+// that is queued, e.g. with WithQueue, or handed off, e.g. with
+// WithExecutor, isn't affected by later calls. Unlike passing a new closure
+// capturing the value to a function returned by New, this doesn't allocate
+// per call, except with the options executing every function passed, e.g.
+// WithQueue.
+func NewArg[T any](after time.Duration, handler func(T), opts ...Option) func(T) {
+	return newArg(after, handler, opts).add
+}
+
+// NewArgWithControls is like NewArg, but also returns functions controlling
+// the debounced function, see NewWithControls. Flush calls handler with the
+// latest value, if one is pending, and does nothing otherwise.
+func NewArgWithControls[T any](after time.Duration, handler func(T), opts ...Option) (func(T), Controls) {
+	a := newArg(after, handler, opts)
+
+	return a.add, a.d.controls()
+}
+
+// NewCall2 is like NewArg, but for a handler taking two values, e.g. to
+// debounce calls to a method without capturing its arguments in a closure.
+// The last pair of values wins.
+func NewCall2[A, B any](after time.Duration, handler func(A, B), opts ...Option) func(A, B) {
+	type args struct {
+		a A
+		b B
+	}
+	add := newArg(after, func(v args) {
+		handler(v.a, v.b)
+	}, opts).add
+
+	return func(a A, b B) {
+		add(args{a, b})
+	}
+}
+
+func newArg[T any](after time.Duration, handler func(T), opts []Option) *arg[T] {
+	a := &arg[T]{d: newDebouncer(after, opts), handler: handler}
+	a.f = func() {}
+	a.key = funcKey(reflect.ValueOf(handler).Pointer())
+	a.d.bind = a.bind
+	a.d.bindDropped = a.bindDropped
+
+	return a
+}
+
+type arg[T any] struct {
+	d       *Debouncer
+	handler func(T)
+
+	// The function scheduled, created once. It's replaced by bind when
+	// taken for execution.
+	f func()
+
+	// The key of handler, see PendingKey.
+	key uint64
+
+	// The latest value, and the value of the call superseded by the call
+	// being scheduled, see WithOnDrop. Guarded by d.mu.
+	v       T
+	dropped T
+}
+
+func (a *arg[T]) add(v T) {
+	d := a.d
+	d.lock()
+	d.callKey = a.key
+	if hasher, ok := d.cfg.valueHasher.(func(T) uint64); ok && hasher != nil {
+		d.callKey = valueKey(a.key, hasher(v))
+	}
+	if d.cfg.orderedAll || d.cfg.collect || d.cfg.accumulate {
+		// Every function is executed, each with its own value.
+		d.schedule(func() { a.handler(v) }, nil, time.Time{})
+		return
+	}
+	if d.replacesPending() {
+		a.dropped, a.v = a.v, v
+	} else {
+		a.dropped = v
+	}
+	d.schedule(a.f, nil, time.Time{})
+}
+
+// bind returns a function calling handler with the latest value.
+// d.mu must be held.
+func (a *arg[T]) bind() func() {
+	v := a.v
+	return func() { a.handler(v) }
+}
+
+// bindDropped returns a function calling handler with the value of the
+// superseded call.
+// d.mu must be held.
+func (a *arg[T]) bindDropped() func() {
+	v := a.dropped
+	var zero T
+	a.dropped = zero
+	return func() { a.handler(v) }
+}
@@ -0,0 +1,233 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestArg(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var got []string
+
+	debounced := debounce.NewArg(50*time.Millisecond, func(s string) {
+		got = append(got, s)
+	}, debounce.WithClock(clock))
+
+	for _, s := range []string{"d", "de", "deb"} {
+		debounced(s)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	debounced("debounce")
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(got) != 2 || got[0] != "deb" || got[1] != "debounce" {
+		t.Error("Expected [deb debounce], got", got)
+	}
+}
+
+func TestArgWithControls(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var got []int
+
+	debounced, controls := debounce.NewArgWithControls(50*time.Millisecond, func(i int) {
+		got = append(got, i)
+	}, debounce.WithClock(clock))
+
+	// No value provided yet.
+	controls.Flush()
+
+	debounced(1)
+	debounced(2)
+	controls.Flush()
+
+	debounced(3)
+	controls.Cancel()
+	controls.Flush()
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(got) != 1 || got[0] != 2 {
+		t.Error("Expected [2], got", got)
+	}
+}
+
+func TestArgCapturedOnExecution(t *testing.T) {
+	t.Run("Queue", func(t *testing.T) {
+		clock := debounce.NewFakeClock(time.Now())
+
+		var got []int
+
+		debounced := debounce.NewArg(50*time.Millisecond, func(i int) {
+			got = append(got, i)
+		}, debounce.WithClock(clock), debounce.WithQueue(10))
+
+		for i := 1; i <= 3; i++ {
+			debounced(i)
+		}
+
+		clock.Advance(time.Second)
+
+		if fmt.Sprint(got) != "[1 2 3]" {
+			t.Error("Expected [1 2 3], got", got)
+		}
+	})
+
+	t.Run("Executor", func(t *testing.T) {
+		clock := debounce.NewFakeClock(time.Now())
+
+		var (
+			got       []int
+			handedOff []func()
+		)
+
+		debounced := debounce.NewArg(50*time.Millisecond, func(i int) {
+			got = append(got, i)
+		}, debounce.WithClock(clock), debounce.WithExecutor(func(f func()) {
+			handedOff = append(handedOff, f)
+		}))
+
+		debounced(1)
+		clock.Advance(100 * time.Millisecond)
+
+		// Called before the first execution has been run by the executor.
+		debounced(2)
+		clock.Advance(100 * time.Millisecond)
+
+		for _, f := range handedOff {
+			f()
+		}
+
+		if fmt.Sprint(got) != "[1 2]" {
+			t.Error("Expected [1 2], got", got)
+		}
+	})
+}
+
+func BenchmarkArg(b *testing.B) {
+	var counter uint64
+
+	debounced := debounce.NewArg(100*time.Millisecond, func(i int) {
+		atomic.AddUint64(&counter, 1)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		debounced(i)
+	}
+
+	c := int(atomic.LoadUint64(&counter))
+	if c != 0 {
+		b.Fatal("Expected count 0, was", c)
+	}
+}
+
+func TestCall2(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var got []string
+
+	debounced := debounce.NewCall2(50*time.Millisecond, func(s string, i int) {
+		got = append(got, fmt.Sprint(s, i))
+	}, debounce.WithClock(clock))
+
+	debounced("a", 1)
+	debounced("b", 2)
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(got) != 1 || got[0] != "b2" {
+		t.Error("Expected [b2], got", got)
+	}
+}
+
+func BenchmarkCall2(b *testing.B) {
+	handler := func(s string, i int) {}
+
+	b.Run("Closure", func(b *testing.B) {
+		debounced := debounce.New(100 * time.Millisecond)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			debounced(func() { handler("a", i) })
+		}
+	})
+
+	b.Run("Call2", func(b *testing.B) {
+		debounced := debounce.NewCall2(100*time.Millisecond, handler)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			debounced("a", i)
+		}
+	})
+}
+
+func TestArgKeepFirst(t *testing.T) {
+	var got []int
+
+	debounced, controls := debounce.NewArgWithControls(time.Hour, func(i int) {
+		got = append(got, i)
+	}, debounce.WithKeepFirst(true))
+
+	debounced(1)
+	debounced(2)
+	debounced(3)
+	controls.Flush()
+
+	if fmt.Sprint(got) != "[1]" {
+		t.Error("Expected [1], got", got)
+	}
+}
+
+func TestArgOnDrop(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     []debounce.Option
+		expected string
+	}{
+		{"Last", nil, "[1 2 3]"},
+		{"KeepFirst", []debounce.Option{debounce.WithKeepFirst(true)}, "[2 3 1]"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				got     []int
+				dropped []func()
+			)
+
+			opts := append(test.opts, debounce.WithOnDrop(func(f func()) {
+				dropped = append(dropped, f)
+			}))
+			debounced, controls := debounce.NewArgWithControls(time.Hour, func(i int) {
+				got = append(got, i)
+			}, opts...)
+
+			debounced(1)
+			debounced(2)
+			debounced(3)
+
+			// The dropped functions call the handler with their values.
+			for _, f := range dropped {
+				f()
+			}
+			controls.Flush()
+
+			if fmt.Sprint(got) != test.expected {
+				t.Errorf("Expected %s, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// Batcher collects values and passes them to a handler in one batch
+// when Add stops being called for the given duration.
+type Batcher[T any] struct {
+	d       *Debouncer
+	handler func([]T)
+
+	// The pending batch. Guarded by d.mu.
+	values []T
+}
+
+// PendingState describes the pending batch of a Batcher.
+// It can be passed to ImportPending on another Batcher, e.g. in another
+// process instance during a live upgrade.
+type PendingState[T any] struct {
+	Values   []T       `json:"values"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// NewBatcher returns a new Batcher that calls handler with the values added
+// when Add stops being called for the given duration.
+func NewBatcher[T any](after time.Duration, handler func([]T), opts ...Option) *Batcher[T] {
+	b := &Batcher[T]{
+		d:       newDebouncer(after, opts),
+		handler: handler,
+	}
+	b.d.bind = b.bind
+
+	return b
+}
+
+// NewBatch returns a function that collects its arguments and calls handler
+// with them in one batch when it stops being called for the given duration.
+// Use WithMaxCalls to handle the batch right away once it holds that many
+// values. The slice passed to handler is not touched by later calls.
+func NewBatch[T any](after time.Duration, handler func([]T), opts ...Option) func(T) {
+	return NewBatcher(after, handler, opts...).Add
+}
+
+// Add adds v to the pending batch.
+func (b *Batcher[T]) Add(v T) {
+	d := b.d
+	d.lock()
+	if d.cfg.orderedAll || d.cfg.collect || d.cfg.accumulate {
+		// Every function is executed, each with its own value.
+		d.schedule(func() { b.handler([]T{v}) }, nil, time.Time{})
+		return
+	}
+	b.reset()
+	b.values = append(b.values, v)
+	d.schedule(noop, nil, time.Time{})
+}
+
+// ExportPending returns the pending values and when they're scheduled to be
+// handled. The bool is false if nothing is pending.
+// The Batcher itself is left untouched.
+func (b *Batcher[T]) ExportPending() (PendingState[T], bool) {
+	b.d.lock()
+	defer b.d.mu.Unlock()
+
+	if !b.d.pending() || len(b.values) == 0 {
+		return PendingState[T]{}, false
+	}
+
+	values := make([]T, len(b.values))
+	copy(values, b.values)
+
+	return PendingState[T]{Values: values, Deadline: b.d.deadline}, true
+}
+
+// ImportPending adds the values in state to the pending batch and schedules
+// it to be handled at state.Deadline, or right away if that has passed.
+func (b *Batcher[T]) ImportPending(state PendingState[T]) {
+	if len(state.Values) == 0 {
+		return
+	}
+
+	b.d.lock()
+	b.reset()
+	b.values = append(b.values, state.Values...)
+	b.d.scheduleAt(noop, state.Deadline)
+}
+
+// reset drops the values of ignored calls, e.g. after Close, when nothing
+// is pending.
+// d.mu must be held.
+func (b *Batcher[T]) reset() {
+	if !b.d.pending() {
+		b.values = nil
+	}
+}
+
+// bind returns a function calling handler with the pending batch.
+// d.mu must be held.
+func (b *Batcher[T]) bind() func() {
+	values := b.values
+	b.values = nil
+	return func() {
+		if len(values) > 0 {
+			b.handler(values)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestBatcherExportImportPending(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		got     []int
+		firedAt time.Time
+	)
+
+	b1 := debounce.NewBatcher(100*time.Millisecond, func([]int) {})
+
+	if _, ok := b1.ExportPending(); ok {
+		t.Fatal("Expected nothing pending")
+	}
+
+	b1.Add(1)
+	b1.Add(2)
+	b1.Add(3)
+
+	state, ok := b1.ExportPending()
+	if !ok {
+		t.Fatal("Expected pending state")
+	}
+
+	if time.Until(state.Deadline) <= 0 {
+		t.Fatal("Expected deadline in the future, got", state.Deadline)
+	}
+
+	// The resumed batch should keep the original timing,
+	// not wait for the longer duration.
+	b2 := debounce.NewBatcher(time.Hour, func(values []int) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = values
+		firedAt = time.Now()
+	})
+
+	b2.ImportPending(state)
+
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatal("Expected [1 2 3], got", got)
+	}
+	if diff := firedAt.Sub(state.Deadline); diff < 0 || diff > 50*time.Millisecond {
+		t.Error("Expected fire at the exported deadline, was off by", diff)
+	}
+}
+
+func TestNewBatch(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var batches [][]int
+
+	add := debounce.NewBatch(50*time.Millisecond, func(values []int) {
+		batches = append(batches, values)
+	}, debounce.WithClock(clock), debounce.WithMaxCalls(3))
+
+	for i := 0; i < 5; i++ {
+		add(i)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	// The batch handed over on reaching the max calls is not mutated by
+	// the values added after it.
+	add(5)
+	clock.Advance(100 * time.Millisecond)
+
+	if got := fmt.Sprint(batches); got != "[[0 1 2] [3 4] [5]]" {
+		t.Error("Expected batches [[0 1 2] [3 4] [5]], got", got)
+	}
+}
+
+func TestNewBatchExecutor(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		got       [][]int
+		handedOff []func()
+	)
+
+	add := debounce.NewBatch(50*time.Millisecond, func(values []int) {
+		got = append(got, values)
+	}, debounce.WithClock(clock), debounce.WithExecutor(func(f func()) {
+		handedOff = append(handedOff, f)
+	}))
+
+	add(1)
+	clock.Advance(100 * time.Millisecond)
+
+	// Added before the first batch has been run by the executor.
+	add(2)
+	clock.Advance(100 * time.Millisecond)
+
+	for _, f := range handedOff {
+		f()
+	}
+
+	if fmt.Sprint(got) != "[[1] [2]]" {
+		t.Error("Expected [[1] [2]], got", got)
+	}
+}
+
+func TestNewBatchMaxCallsConcurrent(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sizes = make(map[int]int)
+		wg    sync.WaitGroup
+	)
+
+	add := debounce.NewBatch(time.Hour, func(values []int) {
+		mu.Lock()
+		sizes[len(values)]++
+		mu.Unlock()
+	}, debounce.WithMaxCalls(10))
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				add(j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sizes) != 1 || sizes[10] != 100 {
+		t.Error("Expected 100 batches of 10, got", sizes)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"context"
+	"time"
+)
+
+// NewCancelable is like New, but the function executed receives a context
+// that is cancelled when the debounced function is called again, e.g. to
+// abort slow work that a newer call makes obsolete.
+//
+// A function that ignores the cancellation keeps executing, but the next
+// one isn't started before it has completed, see WithQueueDuringExecution,
+// unless disabled with WithQueueDuringExecution(false).
+func NewCancelable(after time.Duration, opts ...Option) func(f func(ctx context.Context)) {
+	opts = append([]Option{WithQueueDuringExecution(true)}, opts...)
+	d := newDebouncer(after, opts)
+
+	var cancelPrev context.CancelFunc // Guarded by d.mu.
+
+	return func(f func(ctx context.Context)) {
+		if f == nil {
+			return
+		}
+
+		d.lock()
+		if cancelPrev != nil {
+			cancelPrev()
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelPrev = cancel
+		d.schedule(func() {
+			defer cancel()
+			f(ctx)
+		}, nil, time.Time{})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestNewCancelable(t *testing.T) {
+	var (
+		started   = make(chan struct{})
+		cancelled = make(chan struct{})
+		executed  uint64
+	)
+
+	debounced := debounce.NewCancelable(10 * time.Millisecond)
+
+	debounced(func(ctx context.Context) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+		case <-time.After(time.Second):
+		}
+	})
+
+	<-started
+	debounced(func(ctx context.Context) {
+		atomic.AddUint64(&executed, 1)
+	})
+
+	select {
+	case <-cancelled:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Expected the running function to be cancelled")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&executed); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestNewCancelableNoOverlap(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		active  int
+		overlap bool
+		wg      sync.WaitGroup
+	)
+
+	debounced := debounce.NewCancelable(time.Millisecond)
+
+	f := func(ctx context.Context) {
+		defer wg.Done()
+		mu.Lock()
+		active++
+		overlap = overlap || active > 1
+		mu.Unlock()
+
+		// Ignores the cancellation.
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	wg.Add(1)
+	debounced(f)
+	time.Sleep(10 * time.Millisecond)
+	wg.Add(1)
+	debounced(f)
+	wg.Wait()
+
+	if overlap {
+		t.Error("Expected the executions not to overlap")
+	}
+}
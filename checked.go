@@ -0,0 +1,27 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNilFunc is returned by the function returned by NewChecked when
+// it's called with a nil function.
+var ErrNilFunc = errors.New("debounce: nil function")
+
+// NewChecked is like New, but the debounced function returns ErrNilFunc
+// if it's called with a nil function instead of silently ignoring it.
+func NewChecked(after time.Duration, opts ...Option) func(f func()) error {
+	d := newDebouncer(after, opts)
+
+	return func(f func()) error {
+		if f == nil {
+			return ErrNilFunc
+		}
+		d.add(f)
+		return nil
+	}
+}
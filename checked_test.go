@@ -0,0 +1,63 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestChecked(t *testing.T) {
+	var counter atomic.Uint64
+
+	debounced := debounce.NewChecked(50 * time.Millisecond)
+
+	if err := debounced(nil); !errors.Is(err, debounce.ErrNilFunc) {
+		t.Fatal("Expected ErrNilFunc, got", err)
+	}
+
+	if err := debounced(func() { counter.Add(1) }); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	// A rejected nil function must not replace the pending one.
+	if err := debounced(nil); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := counter.Load(); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestNilFuncIgnored(t *testing.T) {
+	var counter atomic.Uint64
+
+	debounced := debounce.New(50 * time.Millisecond)
+
+	debounced(nil)
+	debounced(func() { counter.Add(1) })
+	debounced(nil)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := counter.Load(); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+
+	// Nothing pending, nothing to execute.
+	debounced(nil)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := counter.Load(); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// clock provides the current time and timers.
+// It can be replaced in tests.
+type clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) timer
+}
+
+type timer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) timer {
+	return time.AfterFunc(d, f)
+}
+
+func withClock(c clock) Option {
+	return Option{
+		kind: "clock",
+		apply: func(cfg *config) {
+			cfg.clock = c
+		},
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"context"
+	"time"
+)
+
+// CloseMode controls what Close does with the pending function.
+type CloseMode int
+
+const (
+	// CloseFlushSync executes the pending function, if any, and waits for it
+	// to complete before Close returns. This is the default and is
+	// recommended for durable workloads, where the last call must not be lost.
+	CloseFlushSync CloseMode = iota
+
+	// CloseDrop discards the pending function, if any.
+	CloseDrop
+
+	// CloseDrain sends the pending function, if any, to the channel
+	// returned by NewWithDrain instead of executing it. Without such a
+	// channel, it behaves like CloseDrop.
+	CloseDrain
+)
+
+// NewWithDrain returns a new Debouncer that uses CloseDrain: on Close, the
+// pending function is sent to the returned channel, which is then closed,
+// so the caller decides how and when to execute it.
+func NewWithDrain(after time.Duration, opts ...Option) (*Debouncer, <-chan func()) {
+	d := newDebouncer(after, opts)
+	d.cfg.closeMode = CloseDrain
+	d.drain = make(chan func(), 1)
+
+	return d, d.drain
+}
+
+// Close stops the Debouncer and its timer. Subsequent calls are ignored,
+// unlike after Cancel, and calling Close again is a no-op.
+// What happens to the pending function is controlled by WithCloseMode.
+// Close waits for executions in flight to complete before returning, so it
+// must not be called from the executed function, nor from a hook running as
+// part of the execution, e.g. the one set with WithOnMaxFires, as it would
+// wait for itself; call it on a new goroutine instead.
+//
+// With CloseFlushSync, a Cancel racing with Close cannot discard
+// the pending function.
+func (d *Debouncer) Close() {
+	d.lock()
+	d.markClosed()
+	d.touchIdle()
+
+	var (
+		f         func()
+		abandoned bool
+	)
+	switch {
+	case !d.pending():
+		// Stop any timer gating a quiet window.
+		d.take()
+	case d.cfg.closeMode == CloseDrain && d.drain != nil:
+		d.drain <- d.take()
+	case d.cfg.closeMode == CloseDrop, d.cfg.closeMode == CloseDrain:
+		d.logDropped(d.take())
+		abandoned = true
+	default:
+		f = d.takeForExecution(ReasonFlush)
+	}
+	if d.drain != nil {
+		// Closed once, even if d was closed before, e.g. by WithMaxFires.
+		close(d.drain)
+		d.drain = nil
+	}
+	// The stopped timer will not be reused.
+	d.spare = nil
+
+	// Wait for the executions in flight, not counting our own.
+	own := 0
+	if f != nil {
+		own = 1
+	}
+	for d.running > own {
+		d.wait()
+	}
+	if f == nil {
+		d.signalDone()
+	}
+	onAbandon := d.cfg.onAbandon
+	d.mu.Unlock()
+
+	if abandoned {
+		if onAbandon != nil {
+			onAbandon()
+		}
+		d.cascadeCancel()
+	}
+
+	if f != nil {
+		d.execute(f)
+	}
+}
+
+// markClosed makes d ignore subsequent calls and removes it from the
+// registry, see WithRegister. It's used by Close and by the options and
+// functions closing d for it, e.g. WithMaxFires.
+// d.mu must be held.
+func (d *Debouncer) markClosed() {
+	d.closed = true
+	d.closeOnce.Do(func() {
+		close(d.closedc)
+		deregister(d)
+	})
+}
+
+// BindContext closes d when ctx is done, using the configured CloseMode,
+// e.g. to tie the Debouncer to the lifetime of a request or a service.
+func (d *Debouncer) BindContext(ctx context.Context) {
+	d.initOnce.Do(d.init)
+	go func() {
+		select {
+		case <-ctx.Done():
+			d.Close()
+		case <-d.closedc:
+		}
+	}()
+}
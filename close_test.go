@@ -0,0 +1,244 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestCloseFlushSyncCompletes(t *testing.T) {
+	var written atomic.Bool
+
+	d := debounce.NewDebouncer(time.Hour)
+
+	d.Do(func() {
+		time.Sleep(50 * time.Millisecond)
+		written.Store(true)
+	})
+
+	d.Close()
+
+	if !written.Load() {
+		t.Fatal("Expected the write to complete before Close returned")
+	}
+
+	d.Do(func() {
+		t.Error("Expected calls after Close to be ignored")
+	})
+	d.Close()
+}
+
+func TestCloseFlushSyncRacingCancel(t *testing.T) {
+	var written atomic.Bool
+
+	d := debounce.NewDebouncer(time.Hour)
+
+	d.Do(func() {
+		time.Sleep(50 * time.Millisecond)
+		written.Store(true)
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		d.Close()
+		close(closed)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	d.Cancel()
+
+	<-closed
+
+	if !written.Load() {
+		t.Error("Expected the write to complete before Close returned")
+	}
+}
+
+func TestCloseWaitsForExecutionInFlight(t *testing.T) {
+	var written atomic.Bool
+
+	d := debounce.NewDebouncer(10 * time.Millisecond)
+
+	d.Do(func() {
+		time.Sleep(100 * time.Millisecond)
+		written.Store(true)
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	d.Close()
+
+	if !written.Load() {
+		t.Error("Expected the execution in flight to complete before Close returned")
+	}
+}
+
+func TestCloseDrop(t *testing.T) {
+	var (
+		counter   uint64
+		abandoned uint64
+	)
+
+	d := debounce.NewDebouncer(
+		10*time.Millisecond,
+		debounce.WithCloseMode(debounce.CloseDrop),
+		debounce.WithOnAbandon(func() {
+			atomic.AddUint64(&abandoned, 1)
+		}),
+	)
+
+	d.Do(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+	d.Close()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 0 {
+		t.Error("Expected count 0, was", c)
+	}
+	if c := atomic.LoadUint64(&abandoned); c != 1 {
+		t.Error("Expected abandoned count 1, was", c)
+	}
+}
+
+func TestCloseDrain(t *testing.T) {
+	var counter uint64
+
+	d, drain := debounce.NewWithDrain(10 * time.Millisecond)
+
+	d.Do(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+	d.Close()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	f, ok := <-drain
+	if !ok || f == nil {
+		t.Fatal("Expected the pending function to be drained")
+	}
+	if _, ok := <-drain; ok {
+		t.Fatal("Expected the drain channel to be closed")
+	}
+
+	f()
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+
+	// Nothing pending.
+	d, drain = debounce.NewWithDrain(10 * time.Millisecond)
+	d.Close()
+	d.Close()
+	if _, ok := <-drain; ok {
+		t.Error("Expected the drain channel to be closed")
+	}
+}
+
+func TestCloseDrainAfterMaxFires(t *testing.T) {
+	d, drain := debounce.NewWithDrain(time.Millisecond, debounce.WithMaxFires(1))
+
+	d.Do(func() {})
+	d.Flush()
+	d.Close()
+
+	select {
+	case _, ok := <-drain:
+		if ok {
+			t.Error("Expected nothing to be drained")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the drain channel to be closed")
+	}
+}
+
+func TestBindContext(t *testing.T) {
+	for _, mode := range []debounce.CloseMode{debounce.CloseFlushSync, debounce.CloseDrop} {
+		var counter uint64
+
+		d := debounce.NewDebouncer(time.Hour, debounce.WithCloseMode(mode))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		d.BindContext(ctx)
+
+		d.Do(func() {
+			atomic.AddUint64(&counter, 1)
+		})
+
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+
+		expected := uint64(1)
+		if mode == debounce.CloseDrop {
+			expected = 0
+		}
+		if c := atomic.LoadUint64(&counter); c != expected {
+			t.Errorf("[mode=%d] Expected count %d, was %d", mode, expected, c)
+		}
+
+		// Closed.
+		d.Do(func() {
+			atomic.AddUint64(&counter, 1)
+		})
+		d.Flush()
+		if c := atomic.LoadUint64(&counter); c != expected {
+			t.Errorf("[mode=%d] Expected count %d, was %d", mode, expected, c)
+		}
+	}
+}
+
+func TestBindContextCloseFirst(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	d := debounce.NewDebouncer(time.Hour)
+	d.BindContext(context.Background())
+	d.Close()
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("Expected the context watcher to exit, got %d goroutines, had %d", n, before)
+	}
+}
+
+func TestCloseFromExecutedFunction(t *testing.T) {
+	d := debounce.NewDebouncer(time.Millisecond)
+
+	closed := make(chan struct{})
+	d.Do(func() {
+		// Close waits for this execution, so it must not block it.
+		go func() {
+			d.Close()
+			close(closed)
+		}()
+	})
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+
+	var executed atomic.Bool
+	d.Do(func() {
+		executed.Store(true)
+	})
+	d.Flush()
+	if executed.Load() {
+		t.Error("Expected calls after Close to be ignored")
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"context"
+	"time"
+)
+
+// NewWithContext is like New, but when ctx is done, the pending function,
+// if any, is discarded and subsequent calls are ignored.
+func NewWithContext(ctx context.Context, after time.Duration, opts ...Option) func(f func()) {
+	d := newDebouncer(after, opts)
+
+	if done := ctx.Done(); done != nil {
+		go func() {
+			<-done
+			d.stop()
+		}()
+	}
+
+	return d.Do
+}
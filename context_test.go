@@ -0,0 +1,61 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestWithContext(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	debounced := debounce.NewWithContext(ctx, 50*time.Millisecond)
+
+	debounced(f)
+	time.Sleep(100 * time.Millisecond)
+
+	debounced(f)
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+
+	debounced(f)
+	time.Sleep(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestWithContextRacingTimer(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		var counter uint64
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		debounced := debounce.NewWithContext(ctx, time.Millisecond)
+
+		debounced(func() {
+			atomic.AddUint64(&counter, 1)
+		})
+		time.Sleep(time.Millisecond)
+		cancel()
+		time.Sleep(5 * time.Millisecond)
+
+		// Either the function ran or it was discarded, never both or twice.
+		if c := atomic.LoadUint64(&counter); c > 1 {
+			t.Fatal("Expected count 0 or 1, was", c)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"context"
+	"time"
+)
+
+// Controls holds functions controlling a debounced function,
+// see NewWithControls.
+type Controls struct {
+	// Cancel discards the pending function, if any.
+	Cancel func()
+
+	// Flush executes the pending function, if any, right away on the
+	// calling goroutine.
+	Flush func()
+
+	// CancelAndFlush is like Flush, but also stops waiting for the quiet
+	// window after a leading execution.
+	CancelAndFlush func()
+
+	// IsPending reports whether a function is scheduled for execution.
+	IsPending func() bool
+
+	// PendingKey returns a hash identifying the pending function, see
+	// Debouncer.PendingKey.
+	PendingKey func() uint64
+
+	// Remaining returns the time left until the pending function is
+	// executed, see Debouncer.Remaining.
+	Remaining func() time.Duration
+
+	// WaitForIdle waits until nothing is pending or executing,
+	// see Debouncer.WaitForIdle.
+	WaitForIdle func(ctx context.Context) error
+
+	// LastRun returns when the most recent execution started,
+	// see Debouncer.LastRun.
+	LastRun func() time.Time
+
+	// Done returns a channel that is closed when the next execution
+	// completes, see Debouncer.Done.
+	Done func() <-chan struct{}
+
+	// Stats returns a snapshot of the statistics.
+	Stats func() Stats
+
+	// Pause and Resume pause and resume the timer, see Debouncer.Pause.
+	Pause  func()
+	Resume func()
+
+	// SetAfter sets the duration to wait for the calls to stop, see
+	// Debouncer.SetAfter.
+	SetAfter func(after time.Duration)
+
+	// Close stops the debounced function for good, see Debouncer.Close.
+	Close func()
+}
+
+// NewWithControls is like New, but also returns functions controlling the
+// debounced function, e.g. to execute the pending function on shutdown.
+func NewWithControls(after time.Duration, opts ...Option) (func(f func()), Controls) {
+	d := newDebouncer(after, opts)
+
+	return d.Do, d.controls()
+}
+
+func (d *Debouncer) controls() Controls {
+	return Controls{
+		Cancel:         d.Cancel,
+		Flush:          d.Flush,
+		CancelAndFlush: d.CancelAndFlush,
+		IsPending:      d.IsPending,
+		PendingKey:     d.PendingKey,
+		Remaining:      d.Remaining,
+		WaitForIdle:    d.WaitForIdle,
+		LastRun:        d.LastRun,
+		Done:           d.Done,
+		Stats:          d.Stats,
+		Pause:          d.Pause,
+		Resume:         d.Resume,
+		SetAfter:       d.SetAfter,
+		Close:          d.Close,
+	}
+}
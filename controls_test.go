@@ -0,0 +1,139 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestControlsFlush(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	debounced, controls := debounce.NewWithControls(50 * time.Millisecond)
+
+	// Nothing pending.
+	controls.Flush()
+
+	debounced(f)
+	debounced(f)
+	controls.Flush()
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Fatal("Expected count 1, was", c)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestControlsIsPending(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced, controls := debounce.NewWithControls(50*time.Millisecond, debounce.WithClock(clock))
+
+	if controls.IsPending() {
+		t.Fatal("Expected nothing pending")
+	}
+
+	var pendingDuringExecution bool
+	debounced(func() {
+		pendingDuringExecution = controls.IsPending()
+	})
+
+	if !controls.IsPending() {
+		t.Fatal("Expected a pending function")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if pendingDuringExecution {
+		t.Error("Expected nothing pending during execution")
+	}
+	if controls.IsPending() {
+		t.Error("Expected nothing pending after execution")
+	}
+}
+
+func TestControlsClose(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var counter int
+
+	f := func() {
+		counter++
+	}
+
+	debounced, controls := debounce.NewWithControls(50*time.Millisecond, debounce.WithClock(clock), debounce.WithCloseMode(debounce.CloseDrop))
+
+	debounced(f)
+	controls.Close()
+	controls.Close()
+
+	debounced(f)
+
+	if controls.IsPending() {
+		t.Fatal("Expected nothing pending after Close")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if counter != 0 {
+		t.Error("Expected count 0, was", counter)
+	}
+
+	// Unlike Cancel, Close cannot be followed by new scheduling.
+	controls.Cancel()
+	debounced(f)
+	clock.Advance(100 * time.Millisecond)
+
+	if counter != 0 {
+		t.Error("Expected count 0, was", counter)
+	}
+}
+
+func TestControlsSetAfter(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var counter int
+
+	f := func() {
+		counter++
+	}
+
+	debounced, controls := debounce.NewWithControls(50*time.Millisecond, debounce.WithClock(clock))
+
+	debounced(f)
+	controls.SetAfter(500 * time.Millisecond)
+
+	// The armed timer keeps its schedule.
+	clock.Advance(60 * time.Millisecond)
+
+	if counter != 1 {
+		t.Fatal("Expected count 1, was", counter)
+	}
+
+	debounced(f)
+	clock.Advance(100 * time.Millisecond)
+
+	if counter != 1 {
+		t.Fatal("Expected count 1, was", counter)
+	}
+
+	clock.Advance(400 * time.Millisecond)
+
+	if counter != 2 {
+		t.Error("Expected count 2, was", counter)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewWithCount is like New, but the function executed receives the number of
+// calls to the debounced function that were coalesced into this execution.
+func NewWithCount(after time.Duration, opts ...Option) func(f func(calls int)) {
+	d := newDebouncer(after, opts)
+
+	return func(f func(calls int)) {
+		d.addCounted(f)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestCount(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		counts []int
+	)
+
+	f := func(calls int) {
+		mu.Lock()
+		counts = append(counts, calls)
+		mu.Unlock()
+	}
+
+	debounced := debounce.NewWithCount(50*time.Millisecond, debounce.WithMaxCalls(3))
+
+	for i := 0; i < 10; i++ {
+		debounced(f)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []int{3, 3, 3, 1}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected counts %v, got %v", expected, counts)
+	}
+	for i, c := range expected {
+		if counts[i] != c {
+			t.Fatalf("Expected counts %v, got %v", expected, counts)
+		}
+	}
+}
+
+func TestCountConcurrent(t *testing.T) {
+	var (
+		wg    sync.WaitGroup
+		total uint64
+	)
+
+	f := func(calls int) {
+		atomic.AddUint64(&total, uint64(calls))
+	}
+
+	debounced := debounce.NewWithCount(20*time.Millisecond, debounce.WithMaxCalls(7))
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			debounced(f)
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&total)); c != 100 {
+		t.Error("Expected total 100, was", c)
+	}
+}
@@ -9,7 +9,9 @@
 package debounce
 
 import (
+	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,27 +19,901 @@ import (
 // This function will be called when the debounced function stops being called
 // for the given duration.
 // The debounced function can be invoked with different functions, if needed,
-// the last one will win.
-func New(after time.Duration) func(f func()) {
-	d := &debouncer{after: after}
+// the last one will win. Calls with a nil function are ignored.
+// For concurrent calls, the last one is the last to record its function,
+// either while holding the Debouncer's lock, in the same critical section
+// that arms the timer, or, while the timer is armed and no limits are
+// configured, atomically without taking the lock, so a call that has
+// returned is never overtaken by an earlier one.
+// A negative duration is treated as zero, see WithZeroValueMode.
+//
+// An armed timer keeps the debounced function's state reachable until it
+// fires. Use WithStopOnCollect to discard the pending function once the
+// debounced function has been garbage collected, or NewDebouncer and Close
+// to stop it explicitly.
+func New(after time.Duration, opts ...Option) func(f func()) {
+	d := newDebouncer(after, opts)
+	if d.cfg.stopOnCollect {
+		return newCollectable(d)
+	}
 
 	return func(f func()) {
 		d.add(f)
 	}
 }
 
-type debouncer struct {
+// NewWithCancel is like New, but also returns a function that cancels
+// the pending function, if any.
+func NewWithCancel(after time.Duration, opts ...Option) (func(f func()), func()) {
+	d := newDebouncer(after, opts)
+
+	return d.Do, d.Cancel
+}
+
+// NewDebouncer returns a new Debouncer that executes the last function passed
+// to Do when Do stops being called for the given duration.
+func NewDebouncer(after time.Duration, opts ...Option) *Debouncer {
+	return newDebouncer(after, opts)
+}
+
+// Debouncer debounces functions passed to Do.
+// The zero value is ready to use, see WithZeroValueMode.
+type Debouncer struct {
+	initOnce sync.Once
+
 	mu    sync.Mutex
 	after time.Duration
-	timer *time.Timer
+	cfg   config
+	clock clock
+
+	// The armed timer, if any, when it expires, and a stopped timer kept
+	// for reuse.
+	timer   timer
+	timerAt time.Time
+	spare   timer
+
+	// The pending function and the number of calls in the current burst.
+	// For functions that want to know about the burst, fi is set instead of f.
+	f     func()
+	fi    func(info FireInfo)
+	calls int
+
+	// The calls counted towards WithMaxCalls in the current call window,
+	// and when it started, see WithCallWindow.
+	windowCalls int
+	windowStart time.Time
+
+	// The pending functions, in submission order, see WithOrderedAll.
+	queue []func(info FireInfo)
+
+	// The identities of the pending functions, see WithAccumulateFuncs.
+	accumulated map[uintptr]bool
+
+	// Binds the pending function to the state it executes with when it's
+	// taken, e.g. the latest value passed to NewArg.
+	bind func() func()
+
+	// Binds the function superseded by the call being scheduled to the
+	// state it would have executed with, for WithOnDrop, see bind.
+	bindDropped func() func()
+
+	// The calls that skip d.mu, see callFast.
+	fast fastPath
+
+	// The keys of the pending function and of the queued ones, see
+	// PendingKey. A zero key is derived from the function.
+	fKey      uint64
+	queueKeys []uint64
+
+	// The key of the function being scheduled, set by NewArg before each
+	// call to schedule.
+	callKey uint64
+
+	// Called without d.mu held when the Debouncer may have become idle,
+	// e.g. to evict it from a Keyed.
+	idleHook func()
+
+	// When the first and the last call in the current burst were made,
+	// and when its max wait window starts, see WithInitialDelay.
+	startWait time.Time
+	lastCall  time.Time
+	waitFrom  time.Time
+
+	// When the last execution was started, see WithStalenessBound.
+	lastRun time.Time
+
+	// Whether the timer is paused, since when, and the deadline it was
+	// paused at, see Pause.
+	paused      bool
+	pausedAt    time.Time
+	pausedUntil time.Time
+
+	// The latest "not before" time requested in the current burst.
+	notBefore time.Time
+
+	// When the armed timer is set to fire, and why.
+	deadline time.Time
+	reason   FireReason
+
+	// The number of executions.
+	fires atomic.Uint64
+
+	// The number of executions in flight, and whether a due fire
+	// is waiting for them to complete.
+	running int
+	waiting bool
+
+	// The number of calls in the burst most recently taken for execution,
+	// and its function.
+	execCalls int
+	current   func()
+
+	// Signaled when an execution completes.
+	idle sync.Cond
+
+	// Fires if the pending function is late, see WithStallDetector.
+	stallTimer timer
+
+	// The timer for the function set by WithFallback.
+	idleTimer timer
+	idleGen   uint64
+
+	// The number of executions started, and whether the
+	// Debouncer has stopped accepting new calls.
+	seq    uint64
+	closed bool
+
+	// Closed by Close.
+	closedc   chan struct{}
+	closeOnce sync.Once
+
+	// Closed when the next execution completes, see Done.
+	donec chan struct{}
+
+	// Receives the pending function on Close, see NewWithDrain.
+	drain chan func()
+
+	// The number of consecutive failed executions.
+	errors int
+
+	// Downstream stages registered with Then.
+	thens []stage
+
+	// The most recently discarded functions, see WithDropLog.
+	dropped []func()
+
+	// Smooths the executions, see WithLeakyBucket.
+	bucket *leakyBucket
+
+	// Fire counters, and burst latencies if tracked.
+	stats     fireCounts
+	latencies *latencies
+
+	// Tracks overlapping executions, see WithExecutionTracker.
+	tracker *executionTracker
+
+	// Source of the jitter, see WithJitter.
+	rand *rand.Rand
+
+	// Incremented every time the timer is armed or stopped, so a stall
+	// watcher can detect that the burst it watches is gone.
+	gen uint64
+}
+
+func newDebouncer(after time.Duration, opts []Option) *Debouncer {
+	d := &Debouncer{after: max(after, 0)}
+	for _, opt := range opts {
+		opt.apply(&d.cfg)
+	}
+	d.initOnce.Do(d.init)
+	if d.cfg.register {
+		register(d)
+	}
+	return d
+}
+
+// init sets up d from its options. For a zero value Debouncer,
+// it's called on first use.
+func (d *Debouncer) init() {
+	d.closedc = make(chan struct{})
+	d.idle.L = &d.mu
+	d.clock = d.cfg.clock
+	if d.clock == nil {
+		d.clock = realClock{}
+	}
+	if d.cfg.zeroValueMode == 0 {
+		d.cfg.zeroValueMode = DefaultZeroValueMode
+	}
+	if d.cfg.latencyTracking {
+		d.latencies = &latencies{}
+	}
+	if d.cfg.leakCapacity > 0 && d.cfg.leakRate > 0 {
+		d.bucket = &leakyBucket{}
+	}
+	if d.cfg.executionTracker {
+		d.tracker = &executionTracker{}
+	}
+	d.touchIdle()
 }
 
-func (d *debouncer) add(f func()) {
+// lock initializes d, if needed, and locks d.mu, merging the calls that
+// skipped it, see callFast.
+func (d *Debouncer) lock() {
+	d.initOnce.Do(d.init)
 	d.mu.Lock()
+	d.mergeFast()
+}
+
+// wait waits for d.idle to be signaled.
+// d.mu must be held.
+func (d *Debouncer) wait() {
+	d.idle.Wait()
+	d.mergeFast()
+}
+
+// Do schedules f for execution, replacing any pending function.
+// A nil f is ignored.
+func (d *Debouncer) Do(f func()) {
+	d.add(f)
+}
+
+// Cancel discards the pending function, if any.
+// New functions can be scheduled after a Cancel.
+func (d *Debouncer) Cancel() {
+	defer d.cascadeCancel()
+
+	d.lock()
+	if !d.pending() {
+		d.mu.Unlock()
+		return
+	}
+	d.logDropped(d.take())
+	d.signalDone()
+	d.touchIdle()
+	onAbandon := d.cfg.onAbandon
+	d.mu.Unlock()
+
+	if onAbandon != nil {
+		onAbandon()
+	}
+}
+
+// SetAfter sets the duration to wait for the calls to stop. It applies from
+// the next call on; an already armed timer keeps its schedule.
+// A negative duration is treated as zero.
+func (d *Debouncer) SetAfter(after time.Duration) {
+	d.lock()
+	d.after = max(after, 0)
+	d.mu.Unlock()
+}
+
+func (d *Debouncer) add(f func()) {
+	if f != nil && d.callFast(f) {
+		return
+	}
+	d.addNotBefore(f, time.Time{})
+}
+
+// addNotBefore schedules f, making sure that the pending function
+// is not executed before notBefore.
+func (d *Debouncer) addNotBefore(f func(), notBefore time.Time) {
+	d.lock()
+	d.schedule(f, nil, notBefore)
+}
+
+func (d *Debouncer) addCounted(fc func(calls int)) {
+	d.addInfo(func(info FireInfo) { fc(info.Calls) })
+}
+
+func (d *Debouncer) addInfo(fi func(info FireInfo)) {
+	d.lock()
+	d.schedule(nil, fi, time.Time{})
+}
+
+// schedule registers a call with the given pending function and (re)arms
+// the timer. At most one of f and fi may be set; if neither is, the call
+// is ignored.
+// d.mu must be held and is released before returning.
+func (d *Debouncer) schedule(f func(), fi func(info FireInfo), notBefore time.Time) CallOutcome {
+	if d.closed || (f == nil && fi == nil) || d.disabled() {
+		d.mu.Unlock()
+		return Ignored
+	}
+
+	if d.queueFull() && d.cfg.queuePolicy == ForceFlush {
+		queued := d.takeForExecution(ReasonFlush)
+		d.mu.Unlock()
+		d.execute(queued)
+		d.lock()
+		return d.schedule(f, fi, notBefore)
+	}
+
+	burstStart := d.timer == nil && !d.pending()
+	if burstStart && d.cfg.preCheck != nil && !d.cfg.preCheck() {
+		d.mu.Unlock()
+		return Ignored
+	}
+
+	if d.cfg.orderedAll {
+		d.enqueue(f, fi)
+		d.mu.Unlock()
+		return Deferred
+	}
+
+	if d.burstGapExceeded() {
+		// Execute the previous burst before this call starts a new one.
+		prev := d.takeForExecution(ReasonQuiet)
+		d.mu.Unlock()
+		d.execute(prev)
+		d.lock()
+		return d.schedule(f, fi, notBefore)
+	}
+
+	// A call that starts a new quiet window is executed
+	// right away if leading is enabled.
+	leading := (d.cfg.leading || d.cfg.throttle) && burstStart
+
+	switch {
+	case d.cfg.collect:
+		d.push(f, fi)
+	case d.cfg.accumulate:
+		d.accumulate(f, fi)
+	case d.cfg.keepFirst && d.pending():
+		// Keep the first function in the burst.
+		if onDrop := d.cfg.onDrop; onDrop != nil {
+			defer onDrop(d.superseded(f, fi))
+		}
+	default:
+		if onDrop := d.cfg.onDrop; onDrop != nil && d.pending() {
+			defer onDrop(d.superseded(d.f, d.fi))
+		}
+		d.f, d.fi, d.fKey = f, fi, d.callKey
+	}
+
+	if d.paused {
+		// Armed on Resume.
+		d.call(d.clock.Now())
+		d.mu.Unlock()
+		return Deferred
+	}
+
+	if d.cfg.commitInterval > 0 {
+		d.commit()
+		d.mu.Unlock()
+		return Deferred
+	}
+
+	if d.cfg.throttle && !leading {
+		d.throttle()
+		d.mu.Unlock()
+		return Deferred
+	}
+
+	now := d.clock.Now()
+	d.call(now)
+	if burstStart && !leading && d.cfg.initialDelay > 0 {
+		// The max wait window starts when the initial delay has passed.
+		d.waitFrom = now.Add(d.cfg.initialDelay)
+		if d.waitFrom.After(notBefore) {
+			notBefore = d.waitFrom
+		}
+	}
+	if notBefore.After(d.notBefore) {
+		d.notBefore = notBefore
+	}
+
+	after := d.interval(now)
+	reason := ReasonQuiet
+	if wait := d.notBefore.Sub(now); wait > 0 {
+		if wait > after {
+			after = wait
+		}
+	} else {
+		switch {
+		case leading:
+			reason = ReasonLeading
+		case d.callLimitReached():
+			reason = ReasonMaxCalls
+		case d.timeLimitReached(now):
+			reason = ReasonMaxWait
+		}
+		// There's nothing to wait for, see ZeroSync.
+		immediate := after == 0 && d.cfg.zeroValueMode == ZeroSync
+
+		if reason != ReasonQuiet || immediate {
+			d.stopTimer()
+			// A leading execution must also wait for the executions of
+			// earlier bursts, e.g. the trailing one, to complete.
+			if d.mustWait() || (leading && d.running > 0) {
+				d.reason = reason
+				d.waiting = true
+				d.mu.Unlock()
+				return Deferred
+			}
+			dropped := d.bucket != nil && d.leakFull() && d.cfg.leakOverflow == LeakDrop
+			f := d.takeForExecution(reason)
+			d.gateLeading(reason, after)
+			outcome := FiredLimit
+			switch {
+			case dropped:
+				outcome = Ignored
+			case f == nil:
+				// Queued or deferred by the bucket, see WithLeakyBucket.
+				outcome = Deferred
+			case leading:
+				outcome = FiredLeading
+			}
+			d.mu.Unlock()
+			d.execute(f)
+			return outcome
+		}
+
+		if d.cfg.maxWait > 0 {
+			if remaining := d.waitFrom.Add(d.cfg.maxWait).Sub(now); remaining < after {
+				after = remaining
+				reason = ReasonMaxWait
+			}
+		}
+		if d.cfg.stalenessBound > 0 {
+			if remaining := d.staleAt().Sub(now); remaining < after {
+				after = remaining
+				reason = ReasonMaxWait
+			}
+		}
+	}
+
+	d.arm(after, reason)
+	d.enableFast()
+	d.mu.Unlock()
+	return Deferred
+}
+
+// superseded returns f, or fi bound to the current burst, for WithOnDrop.
+// d.mu must be held.
+func (d *Debouncer) superseded(f func(), fi func(info FireInfo)) func() {
+	if f != nil && d.bindDropped != nil {
+		return d.bindDropped()
+	}
+	if fi != nil {
+		info := d.fireInfo()
+		return func() { fi(info) }
+	}
+	return f
+}
+
+// commit registers a call and arms the timer for the end of the current
+// commit interval, if not already armed, see WithCommitInterval.
+// d.mu must be held.
+func (d *Debouncer) commit() {
+	now := d.clock.Now()
+	d.call(now)
+	if d.timer == nil {
+		interval := d.cfg.commitInterval
+		d.arm(now.Truncate(interval).Add(interval).Sub(now), ReasonQuiet)
+	}
+}
+
+// interval returns the duration to wait before executing the pending function.
+// d.mu must be held.
+func (d *Debouncer) interval(now time.Time) time.Duration {
+	after := d.after
+	if d.cfg.dynamicAfter != nil {
+		after = d.cfg.dynamicAfter(now)
+	}
+	if d.errors > 0 && d.cfg.backoffFactor > 1 {
+		after = backoff(after, d.cfg.backoffFactor, d.errors, d.cfg.backoffMax)
+	}
+	if d.cfg.jitter > 0 {
+		after += d.jitter()
+	}
+	return after
+}
+
+// scheduleAt schedules f to be executed at the given deadline, regardless
+// of the configured duration.
+// d.mu must be held and is released before returning.
+func (d *Debouncer) scheduleAt(f func(), deadline time.Time) {
 	defer d.mu.Unlock()
 
+	if d.closed {
+		return
+	}
+	d.f, d.fi = f, nil
+	now := d.clock.Now()
+	d.call(now)
+	d.arm(deadline.Sub(now), ReasonQuiet)
+}
+
+// call registers a call in the current burst.
+// d.mu must be held.
+func (d *Debouncer) call(now time.Time) {
+	if d.calls == 0 {
+		d.startWait = now
+		d.waitFrom = now
+	}
+	d.lastCall = now
+	d.calls++
+	if w := d.cfg.callWindow; w > 0 {
+		if d.windowCalls == 0 || now.Sub(d.windowStart) >= w {
+			d.windowStart, d.windowCalls = now, 0
+		}
+		d.windowCalls++
+	}
+	d.stats.scheduled++
+	d.touchIdle()
+}
+
+// arm starts the timer for the pending function,
+// which will execute for the given reason.
+// The timer is reused: a stopped one is kept, and an armed one is only
+// reset if the deadline moves forward. Moving it back is left to fire,
+// which saves resetting the timer on every call in a burst.
+// d.mu must be held.
+func (d *Debouncer) arm(after time.Duration, reason FireReason) {
+	d.reason = reason
+	d.gen++
+	d.deadline = d.clock.Now().Add(after)
+	switch {
+	case d.timer != nil:
+		if d.timerAt.IsZero() || d.deadline.Before(d.timerAt) {
+			d.timer.Reset(after)
+			d.timerAt = d.deadline
+		}
+	case d.spare != nil:
+		d.timer, d.spare = d.spare, nil
+		d.timer.Reset(after)
+		d.timerAt = d.deadline
+	default:
+		d.timer = d.clock.AfterFunc(after, d.fire)
+		d.timerAt = d.deadline
+	}
+	d.watchStall(after, d.gen)
+}
+
+// stopTimer stops the armed timer, if any, and keeps it for reuse.
+// d.mu must be held.
+func (d *Debouncer) stopTimer() {
+	if d.timer == nil {
+		return
+	}
+	d.timer.Stop()
+	d.timer, d.spare = nil, d.timer
+	// Wake up WaitForIdle.
+	d.idle.Broadcast()
+}
+
+func (d *Debouncer) fire() {
+	d.lock()
+	if d.timer == nil {
+		// The timer was stopped after it fired.
+		d.mu.Unlock()
+		return
+	}
+	if now := d.clock.Now(); now.Before(d.deadline) {
+		// The deadline was moved back after the timer was armed.
+		d.timer.Reset(d.deadline.Sub(now))
+		d.timerAt = d.deadline
+		d.enableFast()
+		d.mu.Unlock()
+		return
+	}
+	// The timer has expired, so arm must reset it.
+	d.timerAt = time.Time{}
+	if !d.pending() {
+		// The quiet window after a leading execution has passed.
+		d.stopTimer()
+		d.deadline = time.Time{}
+		d.mu.Unlock()
+		d.callIdleHook()
+		return
+	}
+	if d.cfg.noTrailing && d.reason == ReasonQuiet {
+		// The trailing edge is disabled, see WithTrailing.
+		d.logDropped(d.take())
+		d.signalDone()
+		d.touchIdle()
+		d.mu.Unlock()
+		d.callIdleHook()
+		return
+	}
+	if d.mustWait() {
+		d.waiting = true
+		d.mu.Unlock()
+		return
+	}
+	reason, deadline := d.reason, d.deadline
+	f := d.takeForExecution(reason)
+	d.gateLeading(reason, d.interval(d.clock.Now()))
+	executor := d.cfg.executor
+	if f == nil {
+		// Held by the bucket, see WithLeakyBucket.
+		d.mu.Unlock()
+		return
+	}
+	if executor != nil {
+		f = d.watchHandoff(f, deadline)
+	}
+	d.mu.Unlock()
+	d.executeOn(executor, f)
+}
+
+// mustWait reports whether a due fire must wait for
+// the executions in flight to complete.
+// d.mu must be held.
+func (d *Debouncer) mustWait() bool {
+	return d.cfg.queueDuringExecution && d.running > 0
+}
+
+// execute runs a function taken with takeForExecution.
+func (d *Debouncer) execute(f func()) {
+	for f != nil {
+		f = d.run(f)
+	}
+	d.callIdleHook()
+}
+
+func (d *Debouncer) callIdleHook() {
+	if d.idleHook != nil {
+		d.idleHook()
+	}
+}
+
+// isIdle reports whether no function is pending, no timer is armed and no
+// execution is in flight.
+// d.mu must be held.
+func (d *Debouncer) isIdle() bool {
+	return d.timer == nil && d.running == 0 && !d.pending()
+}
+
+// executeOn is like execute, but hands the execution off to executor,
+// if set, see WithExecutor.
+func (d *Debouncer) executeOn(executor func(f func()), f func()) {
+	if executor == nil {
+		d.execute(f)
+		return
+	}
+	executor(func() {
+		d.execute(f)
+	})
+}
+
+// run runs f and returns the next function to execute,
+// if a fire was waiting for it.
+func (d *Debouncer) run(f func()) func() {
+	completed := false
+	defer func() {
+		if !completed {
+			// f panicked, which keeps unwinding the calling goroutine, so
+			// a fire waiting for this execution is run on another one.
+			if next := d.done(); next != nil {
+				go d.execute(next)
+			}
+		}
+	}()
+
+	d.fires.Add(1)
+	if t := d.tracker; t != nil {
+		t.enter()
+		defer t.exit()
+	}
+	f()
+
+	d.lock()
+	thens := d.thens
+	d.mu.Unlock()
+	for _, s := range thens {
+		s.next(s.f)
+	}
+
+	completed = true
+	return d.done()
+}
+
+// done marks an execution as completed and returns the next function to
+// execute, if a fire was waiting for it.
+func (d *Debouncer) done() func() {
+	d.lock()
+	defer d.mu.Unlock()
+
+	d.running--
+	d.idle.Broadcast()
+	d.signalDone()
+	d.touchIdle()
+	if !d.waiting || d.running > 0 || d.paused {
+		return nil
+	}
+	d.waiting = false
+	if !d.pending() {
+		return nil
+	}
+	reason := d.reason
+	f := d.takeForExecution(reason)
+	d.gateLeading(reason, d.interval(d.clock.Now()))
+	return f
+}
+
+// gateLeading arms the timer to gate the quiet window after an execution
+// that wasn't triggered by the calls stopping, so that the next call isn't
+// executed on the leading edge, see WithLeading. When throttling, every
+// execution starts a new interval.
+// d.mu must be held.
+func (d *Debouncer) gateLeading(reason FireReason, after time.Duration) {
 	if d.timer != nil {
-		d.timer.Stop()
+		return
+	}
+	if d.cfg.throttle || (d.cfg.leading && reason != ReasonQuiet) {
+		d.arm(after, ReasonQuiet)
+	}
+}
+
+// replacesPending reports whether a call replaces the pending function,
+// which WithKeepFirst prevents.
+// d.mu must be held.
+func (d *Debouncer) replacesPending() bool {
+	return !d.cfg.keepFirst || !d.pending()
+}
+
+func (d *Debouncer) pending() bool {
+	return d.f != nil || d.fi != nil || len(d.queue) > 0
+}
+
+// takeForExecution is like take, but also records statistics
+// about the burst that's about to be executed for the given reason.
+// d.mu must be held.
+func (d *Debouncer) takeForExecution(reason FireReason) func() {
+	leak := d.bucket != nil && reason != ReasonFlush
+	if leak && !d.admit(reason) {
+		// Deferred or discarded by the bucket, so not counted.
+		return nil
+	}
+
+	d.stats.countFire(reason)
+	d.stats.countBurst(d.calls)
+	d.execCalls = d.calls
+	if d.latencies != nil {
+		d.latencies.add(d.clock.Now().Sub(d.startWait))
+	}
+	d.running++
+	d.seq++
+	d.lastRun = d.clock.Now()
+	// Recorded for the FireInfo of the function taken.
+	d.reason = reason
+
+	scheduled := d.clock.Now()
+	if d.timer != nil && reason != ReasonFlush {
+		// Fired by the timer.
+		scheduled = d.deadline
+	}
+	info := d.fireInfo()
+
+	var f func()
+	if d.cfg.orderedAll && reason != ReasonFlush {
+		f = d.dequeue()
+	} else {
+		f = d.take()
+	}
+
+	if d.cfg.maxFires > 0 && d.seq >= uint64(d.cfg.maxFires) {
+		// This is the last execution.
+		d.markClosed()
+		if onMaxFires := d.cfg.onMaxFires; onMaxFires != nil {
+			last := f
+			f = func() {
+				last()
+				onMaxFires()
+			}
+		}
+	}
+
+	d.current = f
+
+	if onFireTiming := d.cfg.onFireTiming; onFireTiming != nil {
+		timed := f
+		f = func() {
+			onFireTiming(scheduled, d.clock.Now())
+			timed()
+		}
+	}
+
+	if onExecute := d.cfg.onExecute; onExecute != nil {
+		executed, name := f, d.cfg.name
+		f = func() {
+			start := d.clock.Now()
+			executed()
+			onExecute(name, start)
+		}
+	}
+
+	if newSpan := d.cfg.newSpan; newSpan != nil {
+		f = traced(newSpan, info, f)
+	}
+
+	if leak {
+		f = d.leak(f)
+	}
+
+	return f
+}
+
+// take returns the pending function and resets the burst.
+// The burst's FireInfo is captured before the reset, so a call is always
+// counted in exactly one execution.
+// d.mu must be held.
+func (d *Debouncer) take() func() {
+	handler := d.panicHandler()
+	f := d.f
+	if f != nil && d.bind != nil {
+		f = d.bind()
+	}
+	if fi := d.fi; fi != nil {
+		info := d.fireInfo()
+		f = func() { fi(info) }
+	}
+	if f != nil && handler != nil {
+		f = isolate(handler, f)
+	}
+	if queue := d.queue; len(queue) > 0 {
+		info := d.fireInfo()
+		fns := make([]func(), len(queue))
+		for i, fi := range queue {
+			fns[i] = func() { fi(info) }
+		}
+		f = isolate(handler, fns...)
+	}
+	d.f, d.fi, d.queue = nil, nil, nil
+	d.fKey, d.queueKeys = 0, nil
+	if d.bucket != nil {
+		d.bucket.deferred = false
+	}
+	d.accumulated = nil
+	d.calls = 0
+	d.windowCalls = 0
+	d.startWait = time.Time{}
+	d.waitFrom = time.Time{}
+	d.notBefore = time.Time{}
+	d.deadline = time.Time{}
+	d.gen++
+	d.stopTimer()
+	if d.stallTimer != nil {
+		d.stallTimer.Stop()
+		d.stallTimer = nil
+	}
+	return f
+}
+
+func (d *Debouncer) callLimitReached() bool {
+	calls := d.calls
+	if d.cfg.callWindow > 0 {
+		calls = d.windowCalls
+	}
+	return d.cfg.maxCalls > 0 && calls >= d.cfg.maxCalls
+}
+
+// burstGapExceeded reports whether the pending burst has ended
+// according to WithBurstGap.
+// d.mu must be held.
+func (d *Debouncer) burstGapExceeded() bool {
+	if d.cfg.burstGap <= 0 || !d.pending() || d.mustWait() || d.paused {
+		return false
+	}
+	return d.clock.Now().Sub(d.lastCall) > d.cfg.burstGap
+}
+
+func (d *Debouncer) timeLimitReached(now time.Time) bool {
+	if d.cfg.stalenessBound > 0 && !now.Before(d.staleAt()) {
+		return true
+	}
+	return d.cfg.maxWait > 0 && now.Sub(d.waitFrom) >= d.cfg.maxWait
+}
+
+// staleAt returns when the pending function must be executed according to
+// WithStalenessBound: the bound after the previous execution, or after the
+// first call in the burst if nothing has been executed yet.
+// d.mu must be held.
+func (d *Debouncer) staleAt() time.Time {
+	from := d.lastRun
+	if from.IsZero() {
+		from = d.startWait
 	}
-	d.timer = time.AfterFunc(d.after, f)
+	return from.Add(d.cfg.stalenessBound)
 }
@@ -5,6 +5,7 @@ package debounce_test
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -31,14 +32,16 @@ func TestDebounce(t *testing.T) {
 		atomic.AddUint64(&counter2, 2)
 	}
 
-	debounced := debounce.New(100 * time.Millisecond)
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.New(100*time.Millisecond, debounce.WithClock(clock))
 
 	for i := 0; i < 3; i++ {
 		for j := 0; j < 10; j++ {
 			debounced(f1)
 		}
 
-		time.Sleep(200 * time.Millisecond)
+		clock.Advance(200 * time.Millisecond)
 	}
 
 	for i := 0; i < 4; i++ {
@@ -49,7 +52,7 @@ func TestDebounce(t *testing.T) {
 			debounced(f3)
 		}
 
-		time.Sleep(200 * time.Millisecond)
+		clock.Advance(200 * time.Millisecond)
 	}
 
 	c1 := int(atomic.LoadUint64(&counter1))
@@ -98,13 +101,15 @@ func TestDebounceDelayed(t *testing.T) {
 		atomic.AddUint64(&counter1, 1)
 	}
 
-	debounced := debounce.New(100 * time.Millisecond)
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.New(100*time.Millisecond, debounce.WithClock(clock))
 
-	time.Sleep(110 * time.Millisecond)
+	clock.Advance(110 * time.Millisecond)
 
 	debounced(f1)
 
-	time.Sleep(200 * time.Millisecond)
+	clock.Advance(200 * time.Millisecond)
 
 	c1 := int(atomic.LoadUint64(&counter1))
 	if c1 != 1 {
@@ -113,6 +118,170 @@ func TestDebounceDelayed(t *testing.T) {
 
 }
 
+func TestDebounceCancel(t *testing.T) {
+	var (
+		counter   uint64
+		abandoned uint64
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced, cancel := debounce.NewWithCancel(
+		50*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithOnAbandon(func() {
+			atomic.AddUint64(&abandoned, 1)
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		debounced(f)
+	}
+	cancel()
+	cancel()
+
+	clock.Advance(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 0 {
+		t.Error("Expected count 0, was", c)
+	}
+	if c := int(atomic.LoadUint64(&abandoned)); c != 1 {
+		t.Error("Expected abandoned count 1, was", c)
+	}
+
+	debounced(f)
+
+	clock.Advance(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestDebounceBurstState(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithMaxWait(250*time.Millisecond),
+		debounce.WithMaxCalls(5),
+	)
+
+	for i, test := range []struct {
+		advance  time.Duration
+		deadline time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{50 * time.Millisecond, 150 * time.Millisecond},
+		{50 * time.Millisecond, 200 * time.Millisecond},
+		// Capped by the max wait.
+		{90 * time.Millisecond, 250 * time.Millisecond},
+	} {
+		clock.Advance(test.advance)
+		d.Do(func() {})
+
+		deadline, calls, startWait := debounce.BurstState(d)
+		if got := deadline.Sub(start); got != test.deadline {
+			t.Errorf("Call %d: expected deadline %s, got %s", i, test.deadline, got)
+		}
+		if calls != i+1 {
+			t.Errorf("Call %d: expected %d calls, got %d", i, i+1, calls)
+		}
+		if !startWait.Equal(start) {
+			t.Errorf("Call %d: expected burst to start at %s, got %s", i, start, startWait)
+		}
+	}
+
+	clock.Advance(60 * time.Millisecond)
+
+	if deadline, calls, startWait := debounce.BurstState(d); !deadline.IsZero() || calls != 0 || !startWait.IsZero() {
+		t.Error("Expected burst to be reset, got", deadline, calls, startWait)
+	}
+}
+
+func TestDebounceTimerReuse(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	debounced := debounce.New(20 * time.Millisecond)
+
+	for burst := 1; burst <= 3; burst++ {
+		for i := 0; i < 1000; i++ {
+			debounced(f)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+
+		if c := int(atomic.LoadUint64(&counter)); c != burst {
+			t.Fatalf("Expected count %d, was %d", burst, c)
+		}
+	}
+}
+
+func TestLastFunctionWins(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []int
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		i := i
+		debounced(func() { executed = append(executed, i) })
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(executed) != 1 || executed[0] != 2 {
+		t.Error("Expected the last function to be executed, got", executed)
+	}
+}
+
+func TestLastFunctionWinsConcurrent(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		seq    int
+		winner = -1
+	)
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock))
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				// The sequence numbers are handed out in the order the
+				// calls are made.
+				mu.Lock()
+				seq++
+				n := seq
+				debounced(func() { winner = n })
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	clock.Advance(100 * time.Millisecond)
+
+	if winner != seq {
+		t.Errorf("Expected the function of the last call %d to win, got %d", seq, winner)
+	}
+}
+
 func BenchmarkDebounce(b *testing.B) {
 	var counter uint64
 
@@ -122,6 +291,7 @@ func BenchmarkDebounce(b *testing.B) {
 
 	debounced := debounce.New(100 * time.Millisecond)
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		debounced(f)
@@ -133,6 +303,31 @@ func BenchmarkDebounce(b *testing.B) {
 	}
 }
 
+func BenchmarkConcurrentCalls(b *testing.B) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	debounced := debounce.New(100 * time.Millisecond)
+
+	// At least 64 goroutines.
+	b.SetParallelism((64 + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			debounced(f)
+		}
+	})
+
+	c := int(atomic.LoadUint64(&counter))
+	if c != 0 {
+		b.Fatal("Expected count 0, was", c)
+	}
+}
+
 func ExampleNew() {
 	var counter uint64
 
@@ -155,3 +350,22 @@ func ExampleNew() {
 	fmt.Println("Counter is", c)
 	// Output: Counter is 3
 }
+
+func ExampleNewDebouncer() {
+	d := debounce.NewDebouncer(time.Hour)
+
+	d.Do(func() {
+		fmt.Println("Saved")
+	})
+
+	fmt.Println("Pending:", d.IsPending())
+
+	// E.g. on shutdown.
+	d.Flush()
+
+	fmt.Println("Pending:", d.IsPending())
+	// Output:
+	// Pending: true
+	// Saved
+	// Pending: false
+}
@@ -0,0 +1,27 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// Doer is implemented by Debouncer. Depend on it instead of the concrete
+// type to be able to swap in another implementation, e.g. in tests.
+type Doer interface {
+	Do(f func())
+}
+
+var _ Doer = (*Debouncer)(nil)
+
+// DoerFunc adapts a function, e.g. the one returned by New, to a Doer.
+type DoerFunc func(f func())
+
+// Do calls fn(f).
+func (fn DoerFunc) Do(f func()) {
+	fn(f)
+}
+
+// Immediate is a Doer that executes f right away, without debouncing.
+var Immediate Doer = DoerFunc(func(f func()) {
+	if f != nil {
+		f()
+	}
+})
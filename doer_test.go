@@ -0,0 +1,40 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestDoer(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	for _, test := range []struct {
+		name     string
+		doer     debounce.Doer
+		expected int
+	}{
+		{"Debouncer", debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock)), 1},
+		{"DoerFunc", debounce.DoerFunc(debounce.New(50*time.Millisecond, debounce.WithClock(clock))), 1},
+		{"Immediate", debounce.Immediate, 3},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var counter int
+
+			for i := 0; i < 3; i++ {
+				test.doer.Do(func() { counter++ })
+			}
+			test.doer.Do(nil)
+
+			clock.Advance(100 * time.Millisecond)
+
+			if counter != test.expected {
+				t.Errorf("Expected count %d, was %d", test.expected, counter)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// closedDone is returned by Done when there is nothing to wait for.
+var closedDone = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// Done returns a channel that is closed when the next execution completes,
+// or when the pending function is discarded, e.g. by Cancel or Close. Every
+// execution closes its own channel, so call Done again to wait for the one
+// after. If nothing is pending or executing, the channel is already closed.
+func (d *Debouncer) Done() <-chan struct{} {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if d.donec == nil {
+		if d.running == 0 && !d.pending() {
+			return closedDone
+		}
+		d.donec = make(chan struct{})
+	}
+	return d.donec
+}
+
+// signalDone closes the channel returned by Done, if any.
+// d.mu must be held.
+func (d *Debouncer) signalDone() {
+	if d.donec != nil {
+		close(d.donec)
+		d.donec = nil
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func isClosed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestDone(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock))
+
+	if !isClosed(d.Done()) {
+		t.Fatal("Expected a closed channel with nothing pending")
+	}
+
+	var counter int
+	d.Do(func() { counter++ })
+
+	done := d.Done()
+	if isClosed(done) {
+		t.Fatal("Expected an open channel with a function pending")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if !isClosed(done) || counter != 1 {
+		t.Fatal("Expected the channel to be closed after the execution")
+	}
+
+	// A fresh channel for the next execution.
+	d.Do(func() { counter++ })
+	next := d.Done()
+	if isClosed(next) {
+		t.Fatal("Expected a new open channel")
+	}
+
+	d.Cancel()
+
+	if !isClosed(next) {
+		t.Error("Expected the channel to be closed on Cancel")
+	}
+}
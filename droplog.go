@@ -0,0 +1,28 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// DroppedFuncs returns the most recent pending functions discarded by Cancel
+// or Close, oldest first. See WithDropLog.
+func (d *Debouncer) DroppedFuncs() []func() {
+	d.lock()
+	defer d.mu.Unlock()
+
+	dropped := make([]func(), len(d.dropped))
+	copy(dropped, d.dropped)
+	return dropped
+}
+
+// logDropped records f in the drop log, if enabled.
+// d.mu must be held.
+func (d *Debouncer) logDropped(f func()) {
+	if d.cfg.dropLog <= 0 || f == nil {
+		return
+	}
+	if len(d.dropped) >= d.cfg.dropLog {
+		n := copy(d.dropped, d.dropped[len(d.dropped)-d.cfg.dropLog+1:])
+		d.dropped = d.dropped[:n]
+	}
+	d.dropped = append(d.dropped, f)
+}
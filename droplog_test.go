@@ -0,0 +1,40 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestDropLog(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour, debounce.WithDropLog(3))
+
+	if dropped := d.DroppedFuncs(); len(dropped) != 0 {
+		t.Fatal("Expected no dropped functions, got", len(dropped))
+	}
+
+	var replayed []int
+	for i := 0; i < 5; i++ {
+		i := i
+		d.Do(func() {
+			replayed = append(replayed, i)
+		})
+		d.Cancel()
+	}
+
+	dropped := d.DroppedFuncs()
+	if len(dropped) != 3 {
+		t.Fatal("Expected 3 dropped functions, got", len(dropped))
+	}
+	for _, f := range dropped {
+		f()
+	}
+
+	if len(replayed) != 3 || replayed[0] != 2 || replayed[1] != 3 || replayed[2] != 4 {
+		t.Error("Expected [2 3 4], got", replayed)
+	}
+}
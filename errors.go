@@ -0,0 +1,84 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// NewErr is like New, but for functions that may fail. The error returned by
+// each execution, including nil, is sent on the returned channel. The channel
+// is buffered, see WithErrBuffer; when it's full, the oldest error is
+// discarded so executions never block. The channel is never closed.
+func NewErr(after time.Duration, opts ...Option) (func(f func() error), <-chan error) {
+	d := newDebouncer(after, opts)
+
+	size := d.cfg.errBuffer
+	if size <= 0 {
+		size = 1
+	}
+	errs := make(chan error, size)
+
+	var mu sync.Mutex
+	send := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for {
+			select {
+			case errs <- err:
+				return
+			default:
+			}
+			// Full, discard the oldest.
+			select {
+			case <-errs:
+			default:
+			}
+		}
+	}
+
+	return func(f func() error) {
+		if f == nil {
+			return
+		}
+		d.DoErr(func() error {
+			err := f()
+			send(err)
+			return err
+		})
+	}, errs
+}
+
+// DoErr is like Do, but for functions that may fail.
+// See WithErrorBackoff.
+func (d *Debouncer) DoErr(f func() error) {
+	d.add(func() {
+		d.reportErr(f())
+	})
+}
+
+func (d *Debouncer) reportErr(err error) {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if err != nil {
+		d.errors++
+	} else {
+		d.errors = 0
+	}
+}
+
+// backoff returns after grown by factor for each of the given number of
+// errors, capped to max if max > 0.
+func backoff(after time.Duration, factor float64, errors int, max time.Duration) time.Duration {
+	v := float64(after)
+	for i := 0; i < errors; i++ {
+		v *= factor
+		if max > 0 && v >= float64(max) {
+			return max
+		}
+	}
+	return time.Duration(v)
+}
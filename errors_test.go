@@ -0,0 +1,80 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestErrorBackoff(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithErrorBackoff(2, 500*time.Millisecond),
+	)
+
+	errFailed := errors.New("failed")
+
+	// Schedules f and returns how long it took for it to be executed.
+	run := func(err error) time.Duration {
+		var firedAt time.Time
+		start := clock.Now()
+		d.DoErr(func() error {
+			firedAt = clock.Now()
+			return err
+		})
+		for firedAt.IsZero() {
+			clock.Advance(10 * time.Millisecond)
+		}
+		return firedAt.Sub(start)
+	}
+
+	for i, expected := range []struct {
+		err   error
+		after time.Duration
+	}{
+		{errFailed, 100 * time.Millisecond},
+		{errFailed, 200 * time.Millisecond},
+		{errFailed, 400 * time.Millisecond},
+		{nil, 500 * time.Millisecond},
+		{nil, 100 * time.Millisecond},
+	} {
+		if after := run(expected.err); after != expected.after {
+			t.Errorf("[%d] Expected execution after %s, got %s", i, expected.after, after)
+		}
+	}
+}
+
+func TestNewErr(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	errFailed := errors.New("failed")
+
+	debounced, errs := debounce.NewErr(50*time.Millisecond, debounce.WithClock(clock), debounce.WithErrBuffer(2))
+
+	for _, err := range []error{errFailed, nil, errFailed} {
+		err := err
+		debounced(func() error { return err })
+		clock.Advance(time.Second)
+	}
+
+	// The buffer holds 2, the oldest error was discarded.
+	if err := <-errs; err != nil {
+		t.Error("Expected nil, got", err)
+	}
+	if err := <-errs; !errors.Is(err, errFailed) {
+		t.Error("Expected errFailed, got", err)
+	}
+	select {
+	case err := <-errs:
+		t.Error("Expected no more errors, got", err)
+	default:
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// DebounceEvents returns a function that coalesces rapid file system events,
+// e.g. from fsnotify, and passes the changed paths to handle once the events
+// stop for the given duration. Each path is passed once per burst, in the
+// order it was first seen.
+func DebounceEvents(after time.Duration, handle func(paths []string), opts ...Option) func(path string) {
+	b := NewBatcher(after, func(paths []string) {
+		seen := make(map[string]bool, len(paths))
+		unique := paths[:0]
+		for _, p := range paths {
+			if !seen[p] {
+				seen[p] = true
+				unique = append(unique, p)
+			}
+		}
+		handle(unique)
+	}, opts...)
+
+	return b.Add
+}
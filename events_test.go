@@ -0,0 +1,40 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestDebounceEvents(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls [][]string
+	)
+
+	event := debounce.DebounceEvents(50*time.Millisecond, func(paths []string) {
+		mu.Lock()
+		calls = append(calls, paths)
+		mu.Unlock()
+	})
+
+	for _, p := range []string{"a.txt", "b.txt", "a.txt", "c.txt", "b.txt", "a.txt"} {
+		event(p)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := [][]string{{"a.txt", "b.txt", "c.txt"}}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+}
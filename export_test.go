@@ -0,0 +1,124 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// WithClock sets the clock used by the debouncer.
+var WithClock = withClock
+
+// IsRegistered reports whether d is in the registry used by
+// FlushAllRegistered and CancelAllRegistered.
+func IsRegistered(d *Debouncer) bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	_, found := registry.m[d]
+	return found
+}
+
+// BurstState returns the deadline of the armed timer, the number of calls
+// and the time of the first call in the current burst of d.
+func BurstState(d *Debouncer) (deadline time.Time, calls int, startWait time.Time) {
+	d.lock()
+	defer d.mu.Unlock()
+	return d.deadline, d.calls, d.startWait
+}
+
+// NewKeyedBatchWithLen is like NewKeyedBatch, but also returns a function
+// reporting the number of keys held.
+func NewKeyedBatchWithLen[K comparable, T any](after time.Duration, fn func(K, []T), opts ...Option) (func(K, T), func() int) {
+	kb := newKeyedBatch(after, fn, opts)
+	return kb.add, func() int {
+		kb.mu.Lock()
+		defer kb.mu.Unlock()
+		return len(kb.batches)
+	}
+}
+
+// FakeClock is a clock that only moves when told to.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a new FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc registers f to be run when the clock has advanced by d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{c: c, f: f, when: c.now.Add(d), active: true}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, running the functions of
+// timers that expire on the way, in order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].when.Before(c.timers[j].when)
+		})
+		var next *fakeTimer
+		for _, t := range c.timers {
+			if t.active && !t.when.After(end) {
+				next = t
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		next.active = false
+		if next.when.After(c.now) {
+			c.now = next.when
+		}
+		c.mu.Unlock()
+		next.f()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+type fakeTimer struct {
+	c      *FakeClock
+	f      func()
+	when   time.Time
+	active bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	wasActive := t.active
+	t.active = true
+	t.when = t.c.now.Add(d)
+	return wasActive
+}
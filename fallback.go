@@ -0,0 +1,43 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// touchIdle restarts the idle period for the function set by WithFallback
+// if the Debouncer is idle, or stops it if not.
+// d.mu must be held.
+func (d *Debouncer) touchIdle() {
+	if d.cfg.fallback == nil {
+		return
+	}
+
+	if d.idleTimer != nil {
+		d.idleTimer.Stop()
+		d.idleTimer = nil
+	}
+	d.idleGen++
+
+	if d.closed || d.running > 0 || d.pending() {
+		return
+	}
+
+	gen := d.idleGen
+	d.idleTimer = d.clock.AfterFunc(d.cfg.fallbackIdle, func() {
+		d.onIdle(gen)
+	})
+}
+
+func (d *Debouncer) onIdle(gen uint64) {
+	d.lock()
+	if gen != d.idleGen {
+		d.mu.Unlock()
+		return
+	}
+	d.idleTimer = nil
+	fallback := d.cfg.fallback
+	d.mu.Unlock()
+
+	if fallback != nil {
+		fallback()
+	}
+}
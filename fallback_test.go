@@ -0,0 +1,56 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestFallback(t *testing.T) {
+	var fallbacks uint64
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithFallback(func() {
+			atomic.AddUint64(&fallbacks, 1)
+		}, time.Second),
+	)
+
+	clock.Advance(999 * time.Millisecond)
+	if c := atomic.LoadUint64(&fallbacks); c != 0 {
+		t.Fatal("Expected 0 fallbacks, got", c)
+	}
+	clock.Advance(time.Millisecond)
+	if c := atomic.LoadUint64(&fallbacks); c != 1 {
+		t.Fatal("Expected 1 fallback, got", c)
+	}
+
+	// Only once per idle period.
+	clock.Advance(5 * time.Second)
+	if c := atomic.LoadUint64(&fallbacks); c != 1 {
+		t.Fatal("Expected 1 fallback, got", c)
+	}
+
+	// Not while calls keep arriving.
+	for i := 0; i < 10; i++ {
+		d.Do(func() {})
+		clock.Advance(500 * time.Millisecond)
+	}
+	if c := atomic.LoadUint64(&fallbacks); c != 1 {
+		t.Fatal("Expected 1 fallback, got", c)
+	}
+
+	// The idle period starts when the last execution completes.
+	clock.Advance(time.Second)
+	if c := atomic.LoadUint64(&fallbacks); c != 2 {
+		t.Fatal("Expected 2 fallbacks, got", c)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// fastPath lets the calls in a burst skip d.mu while the timer is armed and
+// all a call would do is replace the pending function and move the deadline,
+// i.e. when no limits or per call hooks are configured. The calls are merged
+// into the Debouncer the next time d.mu is taken, see lock.
+type fastPath struct {
+	// Bit 0 is set while calls may take the fast path, the other bits count
+	// the calls taking it.
+	state atomic.Uint64
+
+	// The latest function and the number of calls taking the fast path.
+	f     atomic.Value
+	calls atomic.Uint64
+
+	// The latest call, as an offset from base.
+	last atomic.Int64
+
+	// Set while the fast path is disabled, protected by d.mu.
+	base  time.Time
+	after time.Duration
+}
+
+// noop replaces the function kept by the fast path once it's merged, so
+// it's not kept reachable.
+func noop() {}
+
+// callFast schedules f without taking d.mu, if possible. It reports whether
+// it did.
+func (d *Debouncer) callFast(f func()) bool {
+	s := &d.fast
+	if s.state.Load()&1 == 0 {
+		return false
+	}
+	// Read the time before counting the call, to keep the section
+	// mergeFast waits for short.
+	now := d.clock.Now()
+	for {
+		st := s.state.Load()
+		if st&1 == 0 {
+			return false
+		}
+		if s.state.CompareAndSwap(st, st+2) {
+			break
+		}
+	}
+
+	s.f.Store(f)
+	s.calls.Add(1)
+	offset := int64(now.Sub(s.base))
+	for {
+		last := s.last.Load()
+		if offset <= last || s.last.CompareAndSwap(last, offset) {
+			break
+		}
+	}
+
+	s.state.Add(^uint64(1))
+	return true
+}
+
+// enableFast lets the next calls take the fast path if the Debouncer is
+// waiting for the calls to stop and nothing but the pending function and
+// the deadline would change.
+// d.mu must be held.
+func (d *Debouncer) enableFast() {
+	if !d.fastEligible() {
+		return
+	}
+	s := &d.fast
+	s.base, s.after = d.lastCall, d.after
+	s.last.Store(0)
+	s.state.Store(1)
+}
+
+// fastEligible reports whether the calls may take the fast path.
+// d.mu must be held.
+func (d *Debouncer) fastEligible() bool {
+	c := &d.cfg
+	return d.timer != nil && d.reason == ReasonQuiet && d.f != nil && d.fi == nil &&
+		len(d.queue) == 0 && d.notBefore.IsZero() && d.after > 0 && d.errors == 0 &&
+		!d.closed && !d.paused && !d.disabled() &&
+		!c.leading && !c.throttle && !c.keepFirst && !c.collect && !c.accumulate && !c.orderedAll &&
+		c.maxCalls == 0 && c.callWindow == 0 && c.maxWait == 0 && c.stalenessBound == 0 &&
+		c.commitInterval == 0 && c.burstGap == 0 && c.dynamicAfter == nil && c.jitter == 0 &&
+		c.onDrop == nil && c.preCheck == nil && c.onStall == nil && c.fallback == nil
+}
+
+// mergeFast disables the fast path and merges the calls that took it.
+// d.mu must be held.
+func (d *Debouncer) mergeFast() {
+	s := &d.fast
+	if s.state.Load()&1 == 0 {
+		// Only enabled with d.mu held.
+		return
+	}
+	for {
+		st := s.state.Load()
+		if s.state.CompareAndSwap(st, st&^1) {
+			break
+		}
+	}
+	for s.state.Load() != 0 {
+		// Wait for the calls taking the fast path.
+		runtime.Gosched()
+	}
+
+	calls := s.calls.Swap(0)
+	if calls == 0 {
+		return
+	}
+	d.f = s.f.Load().(func())
+	s.f.Store(noop)
+	d.lastCall = s.base.Add(time.Duration(s.last.Load()))
+	d.calls += int(calls)
+	d.stats.scheduled += calls
+	d.gen++
+	d.deadline = d.lastCall.Add(s.after)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestFastPath(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []int
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock))
+
+	for i := 0; i < 5; i++ {
+		i := i
+		d.Do(func() {
+			executed = append(executed, i)
+		})
+		clock.Advance(40 * time.Millisecond)
+	}
+
+	if len(executed) != 0 {
+		t.Fatal("Expected the calls to keep moving the deadline, got", executed)
+	}
+	if c := d.PeekCalls(); c != 5 {
+		t.Error("Expected 5 calls, got", c)
+	}
+
+	clock.Advance(10 * time.Millisecond)
+
+	if len(executed) != 1 || executed[0] != 4 {
+		t.Error("Expected the last function to be executed once, got", executed)
+	}
+	if s := d.Stats(); s.Scheduled != 5 {
+		t.Error("Expected 5 scheduled, got", s.Scheduled)
+	}
+}
+
+func TestFastPathConcurrent(t *testing.T) {
+	var counter atomic.Int64
+
+	d := debounce.NewDebouncer(time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				d.Do(func() {
+					counter.Add(1)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c := d.PeekCalls(); c != 64000 {
+		t.Error("Expected 64000 calls, got", c)
+	}
+
+	d.Flush()
+
+	if c := counter.Load(); c != 1 {
+		t.Error("Expected 1 execution, got", c)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "runtime"
+
+// collectable is the handle behind a function returned by New with
+// WithStopOnCollect. The timer references the Debouncer, not the handle,
+// so the handle can be collected while a function is pending.
+type collectable struct {
+	d *Debouncer
+}
+
+func newCollectable(d *Debouncer) func(f func()) {
+	h := &collectable{d: d}
+	runtime.SetFinalizer(h, func(h *collectable) {
+		h.d.stop()
+	})
+
+	return h.do
+}
+
+func (h *collectable) do(f func()) {
+	h.d.add(f)
+}
+
+// stop discards the pending function, if any, and ignores subsequent calls.
+func (d *Debouncer) stop() {
+	d.lock()
+	d.markClosed()
+	d.mu.Unlock()
+	d.Cancel()
+}
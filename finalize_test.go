@@ -0,0 +1,41 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestStopOnCollect(t *testing.T) {
+	var collected atomic.Bool
+
+	func() {
+		debounced := debounce.New(time.Hour, debounce.WithStopOnCollect(true))
+
+		// Only reachable through the pending function.
+		state := new([64]byte)
+		runtime.SetFinalizer(state, func(*[64]byte) {
+			collected.Store(true)
+		})
+
+		debounced(func() {
+			_ = state
+		})
+	}()
+
+	// The first cycle stops the timer, the next ones reclaim the state.
+	for i := 0; i < 20 && !collected.Load(); i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !collected.Load() {
+		t.Error("Expected the state of the abandoned debounced function to be reclaimed")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "context"
+
+// Flush executes the pending function, if any, right away on the calling
+// goroutine. With WithQueueDuringExecution, it first waits for the
+// executions in flight to complete, so it must then not be called from the
+// executed function, as it would wait for itself.
+func (d *Debouncer) Flush() {
+	d.lock()
+	for d.mustWait() {
+		d.wait()
+	}
+	if !d.pending() {
+		d.mu.Unlock()
+		return
+	}
+	f := d.takeForExecution(ReasonFlush)
+	d.mu.Unlock()
+
+	d.execute(f)
+}
+
+// CancelAndFlush stops waiting: it's like Flush, but also stops the timer
+// gating the quiet window after a leading execution, see WithLeading. When it
+// returns, nothing is pending and the next call starts a new burst. Like
+// Flush, it must not be called from the executed function with
+// WithQueueDuringExecution.
+func (d *Debouncer) CancelAndFlush() {
+	d.lock()
+	for d.mustWait() {
+		d.wait()
+	}
+	if !d.pending() {
+		d.take()
+		d.mu.Unlock()
+		return
+	}
+	f := d.takeForExecution(ReasonFlush)
+	d.mu.Unlock()
+
+	d.execute(f)
+}
+
+// FlushWaitCtx is like Flush, but the pending function is executed on a new
+// goroutine, and FlushWaitCtx returns ctx.Err() if ctx is done before the
+// execution has completed. The function keeps executing in the background.
+func (d *Debouncer) FlushWaitCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Flush()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestFlush(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	d := debounce.NewDebouncer(50 * time.Millisecond)
+
+	d.Flush()
+
+	d.Do(f)
+	d.Do(f)
+	d.Flush()
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Fatal("Expected count 1, was", c)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestFlushWaitCtx(t *testing.T) {
+	var counter uint64
+
+	d := debounce.NewDebouncer(time.Hour)
+
+	d.Do(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+
+	if err := d.FlushWaitCtx(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Fatal("Expected count 1, was", c)
+	}
+
+	release := make(chan struct{})
+	d.Do(func() {
+		<-release
+		atomic.AddUint64(&counter, 1)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := d.FlushWaitCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected deadline exceeded, got", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatal("Expected FlushWaitCtx to return at the deadline, took", elapsed)
+	}
+
+	close(release)
+	d.Close()
+
+	if c := int(atomic.LoadUint64(&counter)); c != 2 {
+		t.Error("Expected count 2, was", c)
+	}
+}
+
+func TestCancelAndFlush(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []int
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock), debounce.WithLeading(true))
+
+	for i := 0; i < 3; i++ {
+		i := i
+		d.Do(func() { executed = append(executed, i) })
+	}
+
+	d.CancelAndFlush()
+
+	if len(executed) != 2 || executed[1] != 2 {
+		t.Fatal("Expected the leading and the last function to be executed, got", executed)
+	}
+	if deadline, _, _ := debounce.BurstState(d); d.IsPending() || !deadline.IsZero() {
+		t.Fatal("Expected nothing pending and no timer armed")
+	}
+
+	// Not gated, so this is a leading call.
+	d.Do(func() { executed = append(executed, 3) })
+
+	if len(executed) != 3 {
+		t.Error("Expected a leading execution, got", executed)
+	}
+}
+
+func TestFlushFromExecutedFunction(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour)
+
+	var inner atomic.Bool
+
+	d.Do(func() {
+		d.Do(func() {
+			inner.Store(true)
+		})
+		d.Flush()
+		if !inner.Load() {
+			t.Error("Expected the function scheduled from the executed function to be flushed")
+		}
+	})
+	d.Flush()
+
+	d.Do(func() {
+		d.Do(func() {})
+		d.CancelAndFlush()
+	})
+	d.Flush()
+
+	if d.IsPending() {
+		t.Error("Expected nothing pending")
+	}
+}
@@ -1 +1,3 @@
 module github.com/bep/debounce
+
+go 1.22
@@ -0,0 +1,57 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// ids holds the Debouncers used by DebounceByID.
+var ids = struct {
+	mu sync.Mutex
+	m  map[string]*Debouncer
+}{
+	m: make(map[string]*Debouncer),
+}
+
+// DebounceByID schedules f on a package level Debouncer shared by all
+// callers using the same id, e.g. by unrelated packages that don't have a
+// Debouncer to pass around. The Debouncer is created on first use with the
+// given duration, which is used until the id is forgotten.
+//
+// This is global state: the Debouncers are kept until ForgetID is called,
+// and the ids must be chosen to not collide with those of other packages.
+func DebounceByID(id string, after time.Duration, f func()) {
+	for {
+		ids.mu.Lock()
+		d, found := ids.m[id]
+		if !found {
+			d = newDebouncer(after, nil)
+			ids.m[id] = d
+		}
+		ids.mu.Unlock()
+
+		d.lock()
+		if !d.closed {
+			d.schedule(f, nil, time.Time{})
+			return
+		}
+		// Forgotten after the lookup.
+		d.mu.Unlock()
+	}
+}
+
+// ForgetID discards the pending function for id, if any, and removes its
+// Debouncer. The next DebounceByID with id starts over.
+func ForgetID(id string) {
+	ids.mu.Lock()
+	d, found := ids.m[id]
+	delete(ids.m, id)
+	ids.mu.Unlock()
+
+	if found {
+		d.stop()
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestDebounceByID(t *testing.T) {
+	var (
+		wg         sync.WaitGroup
+		counterA   uint64
+		counterB   uint64
+		id         = t.Name()
+		otherID    = t.Name() + "/other"
+		incrementA = func() { atomic.AddUint64(&counterA, 1) }
+		incrementB = func() { atomic.AddUint64(&counterB, 1) }
+	)
+	defer debounce.ForgetID(id)
+	defer debounce.ForgetID(otherID)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			debounce.DebounceByID(id, 20*time.Millisecond, incrementA)
+			debounce.DebounceByID(otherID, 20*time.Millisecond, incrementB)
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if a, b := atomic.LoadUint64(&counterA), atomic.LoadUint64(&counterB); a != 1 || b != 1 {
+		t.Errorf("Expected one execution per id, got %d and %d", a, b)
+	}
+}
+
+func TestForgetID(t *testing.T) {
+	var counter uint64
+
+	id := t.Name()
+
+	debounce.DebounceByID(id, 20*time.Millisecond, func() {
+		atomic.AddUint64(&counter, 1)
+	})
+	debounce.ForgetID(id)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	// Starts over.
+	debounce.DebounceByID(id, 20*time.Millisecond, func() {
+		atomic.AddUint64(&counter, 1)
+	})
+	defer debounce.ForgetID(id)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
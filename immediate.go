@@ -0,0 +1,17 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewImmediate returns a debounced function that executes the first call in
+// a burst right away, and the last one when the calls stop for the given
+// duration, if there were any calls after the first one.
+// It's the same as New with WithLeading(true).
+func NewImmediate(after time.Duration, opts ...Option) func(f func()) {
+	d := newDebouncer(after, opts)
+	d.cfg.leading = true
+
+	return d.Do
+}
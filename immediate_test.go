@@ -0,0 +1,41 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestNewImmediate(t *testing.T) {
+	for _, test := range []struct {
+		calls    int
+		expected int
+	}{
+		{1, 1},
+		{3, 2},
+	} {
+		clock := debounce.NewFakeClock(time.Now())
+
+		var counter int
+
+		debounced := debounce.NewImmediate(50*time.Millisecond, debounce.WithClock(clock))
+
+		for i := 0; i < test.calls; i++ {
+			debounced(func() { counter++ })
+			if counter != 1 {
+				t.Fatal("Expected the first call to be executed right away, count was", counter)
+			}
+			clock.Advance(10 * time.Millisecond)
+		}
+
+		clock.Advance(100 * time.Millisecond)
+
+		if counter != test.expected {
+			t.Errorf("Expected count %d for %d calls, was %d", test.expected, test.calls, counter)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// FireInfo describes the burst of calls that led to an execution.
+type FireInfo struct {
+	// The number of calls coalesced into this execution.
+	Calls int
+
+	// The time from the first call in the burst to the execution.
+	Elapsed time.Duration
+
+	// Why the function was executed.
+	Reason FireReason
+
+	// The sequence number of this execution, starting at 1.
+	Seq uint64
+
+	// The name of the Debouncer, see WithName.
+	Name string
+}
+
+// NewWithInfo is like New, but the function executed receives a FireInfo
+// describing the burst it was executed for.
+func NewWithInfo(after time.Duration, opts ...Option) func(f func(info FireInfo)) {
+	d := newDebouncer(after, opts)
+
+	return func(f func(info FireInfo)) {
+		d.addInfo(f)
+	}
+}
+
+// fireInfo returns the FireInfo for the current burst.
+// d.mu must be held.
+func (d *Debouncer) fireInfo() FireInfo {
+	return FireInfo{
+		Calls:   d.calls,
+		Elapsed: d.clock.Now().Sub(d.startWait),
+		Reason:  d.reason,
+		Seq:     d.seq,
+		Name:    d.cfg.name,
+	}
+}
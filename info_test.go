@@ -0,0 +1,81 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestWithInfo(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     []debounce.Option
+		calls    int
+		expected []debounce.FireInfo
+	}{
+		{
+			name:  "quiet",
+			calls: 3,
+			expected: []debounce.FireInfo{
+				{Calls: 3, Elapsed: 70 * time.Millisecond, Reason: debounce.ReasonQuiet, Seq: 1},
+			},
+		},
+		{
+			name:  "max calls",
+			opts:  []debounce.Option{debounce.WithMaxCalls(2)},
+			calls: 3,
+			expected: []debounce.FireInfo{
+				{Calls: 2, Elapsed: 10 * time.Millisecond, Reason: debounce.ReasonMaxCalls, Seq: 1},
+				{Calls: 1, Elapsed: 50 * time.Millisecond, Reason: debounce.ReasonQuiet, Seq: 2},
+			},
+		},
+		{
+			name:  "max wait",
+			opts:  []debounce.Option{debounce.WithMaxWait(15 * time.Millisecond)},
+			calls: 3,
+			expected: []debounce.FireInfo{
+				{Calls: 2, Elapsed: 15 * time.Millisecond, Reason: debounce.ReasonMaxWait, Seq: 1},
+				{Calls: 1, Elapsed: 15 * time.Millisecond, Reason: debounce.ReasonMaxWait, Seq: 2},
+			},
+		},
+		{
+			name:  "leading",
+			opts:  []debounce.Option{debounce.WithLeading(true)},
+			calls: 3,
+			expected: []debounce.FireInfo{
+				{Calls: 1, Elapsed: 0, Reason: debounce.ReasonLeading, Seq: 1},
+				{Calls: 2, Elapsed: 60 * time.Millisecond, Reason: debounce.ReasonQuiet, Seq: 2},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+
+			var got []debounce.FireInfo
+
+			debounced := debounce.NewWithInfo(50*time.Millisecond, append(test.opts, debounce.WithClock(clock))...)
+
+			for i := 0; i < test.calls; i++ {
+				debounced(func(info debounce.FireInfo) {
+					got = append(got, info)
+				})
+				clock.Advance(10 * time.Millisecond)
+			}
+
+			clock.Advance(100 * time.Millisecond)
+
+			if len(got) != len(test.expected) {
+				t.Fatalf("Expected %d executions, got %v", len(test.expected), got)
+			}
+			for i, info := range got {
+				if info != test.expected[i] {
+					t.Errorf("Expected %+v, got %+v", test.expected[i], info)
+				}
+			}
+		})
+	}
+}
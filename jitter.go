@@ -0,0 +1,20 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// jitter returns a random duration in [0, max), see WithJitter.
+// Each Debouncer has its own source, so they don't contend
+// for the global one.
+// d.mu must be held.
+func (d *Debouncer) jitter() time.Duration {
+	if d.rand == nil {
+		d.rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return time.Duration(d.rand.Int64N(int64(d.cfg.jitter)))
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"reflect"
+)
+
+// PendingKey returns a stable hash identifying the pending function,
+// e.g. to key caches on what's about to be executed. When the functions are
+// queued, e.g. with WithQueue, it identifies the next one to execute.
+// It returns 0 if nothing is pending.
+//
+// The key is derived from the function's code pointer, so closures created
+// from the same function literal share a key. For the debounced functions
+// returned by NewArg and NewArgWithControls, it's derived from the handler
+// and, if set with WithValueHasher, the hash of the pending value.
+func (d *Debouncer) PendingKey() uint64 {
+	d.lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case d.f != nil || d.fi != nil:
+		if d.fKey != 0 {
+			return d.fKey
+		}
+		return pendingKey(d.f, d.fi)
+	case len(d.queueKeys) > 0:
+		return d.queueKeys[0]
+	}
+	return 0
+}
+
+// pendingKey returns the key of the set one of f and fi.
+func pendingKey(f func(), fi func(info FireInfo)) uint64 {
+	if fi != nil {
+		return funcKey(reflect.ValueOf(fi).Pointer())
+	}
+	return funcKey(reflect.ValueOf(f).Pointer())
+}
+
+func funcKey(p uintptr) uint64 {
+	return hashKey(uint64(p))
+}
+
+// valueKey returns the key of a handler, identified by its key, called with
+// a value hashed to h.
+func valueKey(handler, h uint64) uint64 {
+	return hashKey(handler, h)
+}
+
+func hashKey(words ...uint64) uint64 {
+	var b [8]byte
+	h := fnv.New64a()
+	for _, w := range words {
+		binary.LittleEndian.PutUint64(b[:], w)
+		h.Write(b[:])
+	}
+	if k := h.Sum64(); k != 0 {
+		return k
+	}
+	// 0 is reserved for nothing pending.
+	return 1
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestPendingKey(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock))
+
+	if k := d.PendingKey(); k != 0 {
+		t.Fatal("Expected key 0, got", k)
+	}
+
+	f1 := func() {}
+	f2 := func() {}
+
+	d.Do(f1)
+	k1 := d.PendingKey()
+	if k1 == 0 {
+		t.Fatal("Expected a key")
+	}
+	if k := d.PendingKey(); k != k1 {
+		t.Fatalf("Expected stable key %d, got %d", k1, k)
+	}
+
+	d.Do(f1)
+	if k := d.PendingKey(); k != k1 {
+		t.Fatalf("Expected stable key %d, got %d", k1, k)
+	}
+
+	d.Do(f2)
+	if k := d.PendingKey(); k == k1 || k == 0 {
+		t.Fatal("Expected a new key, got", k)
+	}
+
+	clock.Advance(time.Second)
+
+	if k := d.PendingKey(); k != 0 {
+		t.Error("Expected key 0 after execution, got", k)
+	}
+}
+
+func TestPendingKeyQueue(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock), debounce.WithQueue(10))
+
+	f1 := func() {}
+	f2 := func() {}
+
+	d.Do(f1)
+	k1 := d.PendingKey()
+	if k1 == 0 {
+		t.Fatal("Expected a key for the queued function")
+	}
+
+	d.Do(f2)
+	if k := d.PendingKey(); k != k1 {
+		t.Fatalf("Expected the key of the head of the queue %d, got %d", k1, k)
+	}
+
+	d.Flush()
+
+	if k := d.PendingKey(); k != 0 {
+		t.Error("Expected key 0 after execution, got", k)
+	}
+}
+
+func TestPendingKeyValueHasher(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced, controls := debounce.NewArgWithControls(100*time.Millisecond, func(i int) {},
+		debounce.WithClock(clock), debounce.WithValueHasher(func(i int) uint64 {
+			return uint64(i)
+		}))
+
+	debounced(1)
+	k1 := controls.PendingKey()
+	if k1 == 0 {
+		t.Fatal("Expected a key")
+	}
+
+	debounced(1)
+	if k := controls.PendingKey(); k != k1 {
+		t.Fatalf("Expected stable key %d, got %d", k1, k)
+	}
+
+	debounced(2)
+	if k := controls.PendingKey(); k == k1 || k == 0 {
+		t.Fatal("Expected a new key for a new value, got", k)
+	}
+
+	clock.Advance(time.Second)
+
+	if k := controls.PendingKey(); k != 0 {
+		t.Error("Expected key 0 after execution, got", k)
+	}
+}
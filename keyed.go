@@ -0,0 +1,103 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// Keyed debounces functions per key, e.g. per file path.
+type Keyed[K comparable] struct {
+	after time.Duration
+	opts  []Option
+
+	mu sync.Mutex
+	m  map[K]*Debouncer
+}
+
+// NewKeyed returns a new Keyed where each key is debounced independently
+// using the given duration and options. A key is removed when its Debouncer
+// is idle, with nothing pending, no execution in flight and no quiet window
+// armed, see WithLeading, so keys don't accumulate.
+func NewKeyed[K comparable](after time.Duration, opts ...Option) *Keyed[K] {
+	return &Keyed[K]{after: after, opts: opts, m: make(map[K]*Debouncer)}
+}
+
+// Do schedules f for execution for key, replacing any function pending
+// for key.
+func (k *Keyed[K]) Do(key K, f func()) {
+	if f == nil {
+		return
+	}
+
+	for {
+		d := k.get(key)
+		d.lock()
+		if !d.closed {
+			d.schedule(f, nil, time.Time{})
+			return
+		}
+		// Evicted after the lookup, or closed, e.g. by WithMaxFires.
+		d.mu.Unlock()
+		k.remove(key, d)
+	}
+}
+
+// Delete removes key, discarding its pending function, if any.
+func (k *Keyed[K]) Delete(key K) {
+	k.mu.Lock()
+	d, found := k.m[key]
+	delete(k.m, key)
+	k.mu.Unlock()
+
+	if found {
+		d.Cancel()
+	}
+}
+
+// Len returns the number of keys.
+func (k *Keyed[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.m)
+}
+
+// get returns the Debouncer for key, creating it if needed.
+func (k *Keyed[K]) get(key K) *Debouncer {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	d, found := k.m[key]
+	if !found {
+		d = newDebouncer(k.after, k.opts)
+		d.idleHook = func() {
+			k.evict(key, d)
+		}
+		k.m[key] = d
+	}
+	return d
+}
+
+// evict closes d and removes key if it's still handled by d and d is idle.
+func (k *Keyed[K]) evict(key K, d *Debouncer) {
+	d.lock()
+	idle := d.isIdle()
+	if idle {
+		d.markClosed()
+	}
+	d.mu.Unlock()
+
+	if idle {
+		k.remove(key, d)
+	}
+}
+
+// remove removes key if it's still handled by d.
+func (k *Keyed[K]) remove(key K, d *Debouncer) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.m[key] == d {
+		delete(k.m, key)
+	}
+}
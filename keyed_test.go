@@ -0,0 +1,73 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestKeyed(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []string
+
+	k := debounce.NewKeyed[string](50*time.Millisecond, debounce.WithClock(clock))
+
+	for _, key := range []string{"a", "b", "a", "c", "a"} {
+		key := key
+		k.Do(key, func() {
+			executed = append(executed, key)
+		})
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	if n := k.Len(); n != 3 {
+		t.Fatal("Expected 3 keys, got", n)
+	}
+
+	k.Delete("c")
+
+	clock.Advance(100 * time.Millisecond)
+
+	sort.Strings(executed)
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+	if n := k.Len(); n != 0 {
+		t.Error("Expected executed keys to be removed, got", n)
+	}
+}
+
+func TestKeyedLeading(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed int
+
+	k := debounce.NewKeyed[string](100*time.Millisecond, debounce.WithClock(clock), debounce.WithLeading(true))
+
+	for i := 0; i < 5; i++ {
+		k.Do("a", func() {
+			executed++
+		})
+		clock.Advance(time.Millisecond)
+	}
+
+	if n := k.Len(); n != 1 {
+		t.Error("Expected the key to be kept during the quiet window, got", n)
+	}
+
+	clock.Advance(time.Second)
+
+	if executed != 2 {
+		t.Error("Expected 2 executions, got", executed)
+	}
+	if n := k.Len(); n != 0 {
+		t.Error("Expected idle key to be removed, got", n)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// NewKeyedBatch returns a function that collects values per key. Each key is
+// debounced independently: when a key stops receiving values for the given
+// duration, fn is called with the key and its values. A key is evicted when
+// its Debouncer is idle, see NewKeyed. Use WithMaxCalls to bound the size of
+// a batch.
+func NewKeyedBatch[K comparable, T any](after time.Duration, fn func(K, []T), opts ...Option) func(K, T) {
+	return newKeyedBatch(after, fn, opts).add
+}
+
+func newKeyedBatch[K comparable, T any](after time.Duration, fn func(K, []T), opts []Option) *keyedBatch[K, T] {
+	return &keyedBatch[K, T]{
+		after:   after,
+		opts:    opts,
+		fn:      fn,
+		batches: make(map[K]*keyBatch[T]),
+	}
+}
+
+type keyedBatch[K comparable, T any] struct {
+	after time.Duration
+	opts  []Option
+	fn    func(K, []T)
+
+	mu      sync.Mutex
+	batches map[K]*keyBatch[T]
+}
+
+type keyBatch[T any] struct {
+	d *Debouncer
+
+	// Protected by d.mu.
+	values []T
+}
+
+func (kb *keyedBatch[K, T]) add(k K, v T) {
+	for {
+		b := kb.get(k)
+		d := b.d
+		d.lock()
+		if !d.closed {
+			b.values = append(b.values, v)
+			d.schedule(func() {}, nil, time.Time{})
+			return
+		}
+		// Evicted after the lookup, or closed, e.g. by WithMaxFires.
+		d.mu.Unlock()
+		kb.remove(k, b)
+	}
+}
+
+// get returns the batch for k, creating it if needed.
+func (kb *keyedBatch[K, T]) get(k K) *keyBatch[T] {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+	b, found := kb.batches[k]
+	if !found {
+		b = &keyBatch[T]{d: newDebouncer(kb.after, kb.opts)}
+		b.d.bind = func() func() {
+			// Values added after this go into the next batch.
+			values := b.values
+			b.values = nil
+			return func() {
+				kb.fn(k, values)
+			}
+		}
+		b.d.idleHook = func() {
+			kb.evict(k, b)
+		}
+		kb.batches[k] = b
+	}
+	return b
+}
+
+// evict closes the Debouncer of b and removes k if it's still handled by b
+// and the Debouncer is idle.
+func (kb *keyedBatch[K, T]) evict(k K, b *keyBatch[T]) {
+	b.d.lock()
+	idle := b.d.isIdle()
+	if idle {
+		b.d.markClosed()
+	}
+	b.d.mu.Unlock()
+
+	if idle {
+		kb.remove(k, b)
+	}
+}
+
+// remove removes k if it's still handled by b.
+func (kb *keyedBatch[K, T]) remove(k K, b *keyBatch[T]) {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+	if kb.batches[k] == b {
+		delete(kb.batches, k)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestKeyedBatch(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches = make(map[string][][]int)
+	)
+
+	add := debounce.NewKeyedBatch(50*time.Millisecond, func(k string, values []int) {
+		mu.Lock()
+		batches[k] = append(batches[k], values)
+		mu.Unlock()
+	})
+
+	add("a", 1)
+	add("b", 10)
+	add("a", 2)
+	add("b", 20)
+	add("a", 3)
+
+	time.Sleep(30 * time.Millisecond)
+
+	// Keeps b pending longer than a.
+	add("b", 30)
+
+	time.Sleep(30 * time.Millisecond)
+
+	mu.Lock()
+	if expected := map[string][][]int{"a": {{1, 2, 3}}}; !reflect.DeepEqual(batches, expected) {
+		t.Fatalf("Expected %v, got %v", expected, batches)
+	}
+	mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := map[string][][]int{"a": {{1, 2, 3}}, "b": {{10, 20, 30}}}
+	if !reflect.DeepEqual(batches, expected) {
+		t.Errorf("Expected %v, got %v", expected, batches)
+	}
+}
+
+func TestKeyedBatchMaxCalls(t *testing.T) {
+	var got [][]int
+
+	add := debounce.NewKeyedBatch(time.Hour, func(k string, values []int) {
+		got = append(got, values)
+	}, debounce.WithMaxCalls(2))
+
+	for i := 0; i < 5; i++ {
+		add("a", i)
+	}
+
+	if expected := [][]int{{0, 1}, {2, 3}}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestKeyedBatchEviction(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var handled int
+
+	add, keys := debounce.NewKeyedBatchWithLen(50*time.Millisecond, func(k int, values []int) {
+		handled += len(values)
+	}, debounce.WithClock(clock), debounce.WithMaxCalls(3))
+
+	for k := 0; k < 1000; k++ {
+		add(k, 1)
+		add(k, 2)
+	}
+
+	if n := keys(); n != 1000 {
+		t.Fatal("Expected 1000 keys, got", n)
+	}
+
+	// Reaching the max calls flushes and evicts the key right away.
+	add(0, 3)
+	if n := keys(); n != 999 {
+		t.Fatal("Expected 999 keys, got", n)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if n := keys(); n != 0 {
+		t.Error("Expected all flushed keys to be evicted, got", n)
+	}
+	if handled != 2001 {
+		t.Error("Expected 2001 values handled, got", handled)
+	}
+}
+
+func TestKeyedBatchLeading(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var got [][]int
+
+	add, keys := debounce.NewKeyedBatchWithLen(50*time.Millisecond, func(k string, values []int) {
+		got = append(got, values)
+	}, debounce.WithClock(clock), debounce.WithLeading(true))
+
+	for i := 0; i < 5; i++ {
+		add("a", i)
+		clock.Advance(time.Millisecond)
+	}
+
+	clock.Advance(time.Second)
+
+	if expected := [][]int{{0}, {1, 2, 3, 4}}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if n := keys(); n != 0 {
+		t.Error("Expected idle key to be evicted, got", n)
+	}
+}
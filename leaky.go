@@ -0,0 +1,92 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// LeakOverflow controls what happens to executions that don't fit in the
+// bucket set up by WithLeakyBucket.
+type LeakOverflow int
+
+const (
+	// LeakDrop discards the execution.
+	LeakDrop LeakOverflow = iota
+
+	// LeakDefer keeps the last execution that didn't fit, and adds it to the
+	// bucket when there's room.
+	LeakDefer
+)
+
+type leakyBucket struct {
+	queue []func()
+
+	// Set while a burst that didn't fit is kept pending, see LeakDefer,
+	// with the reason it fired for.
+	deferred bool
+	reason   FireReason
+
+	// Armed while executions are being released.
+	timer timer
+}
+
+// admit decides whether an execution for the given reason goes through the
+// bucket before it's taken and counted. A burst that doesn't fit is kept
+// pending with LeakDefer, so later calls still replace its function, or
+// discarded with LeakDrop.
+// d.mu must be held.
+func (d *Debouncer) admit(reason FireReason) bool {
+	b := d.bucket
+	if !d.leakFull() {
+		return true
+	}
+	if d.cfg.leakOverflow == LeakDefer {
+		b.deferred, b.reason = true, reason
+		return false
+	}
+	d.take()
+	return false
+}
+
+// leakFull reports whether the bucket has no room for another execution.
+// d.mu must be held.
+func (d *Debouncer) leakFull() bool {
+	b := d.bucket
+	return b.timer != nil && len(b.queue) >= d.cfg.leakCapacity
+}
+
+// leak passes f, admitted by admit, through the bucket. It returns f if it
+// can be executed right away, nil if it's queued.
+// d.mu must be held.
+func (d *Debouncer) leak(f func()) func() {
+	b := d.bucket
+
+	if b.timer == nil {
+		b.timer = d.clock.AfterFunc(d.cfg.leakRate, d.onLeak)
+		return f
+	}
+
+	b.queue = append(b.queue, f)
+	return nil
+}
+
+// onLeak releases the next execution from the bucket.
+func (d *Debouncer) onLeak() {
+	d.lock()
+	b := d.bucket
+	if len(b.queue) == 0 {
+		b.timer = nil
+		d.mu.Unlock()
+		return
+	}
+
+	f := b.queue[0]
+	b.queue = b.queue[1:]
+	if b.deferred && d.pending() {
+		// Queued now that there's room.
+		d.takeForExecution(b.reason)
+	}
+	b.timer = d.clock.AfterFunc(d.cfg.leakRate, d.onLeak)
+	executor := d.cfg.executor
+	d.mu.Unlock()
+
+	d.executeOn(executor, f)
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestLeakyBucket(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		overflow debounce.LeakOverflow
+		expected []int
+	}{
+		{"Drop", debounce.LeakDrop, []int{0, 1, 2}},
+		{"Defer", debounce.LeakDefer, []int{0, 1, 2, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+			start := clock.Now()
+
+			var (
+				executed []int
+				at       []time.Duration
+			)
+
+			// Every call fires right away because of WithMaxCalls(1).
+			d := debounce.NewDebouncer(
+				time.Hour,
+				debounce.WithClock(clock),
+				debounce.WithMaxCalls(1),
+				debounce.WithLeakyBucket(2, 100*time.Millisecond),
+				debounce.WithLeakOverflow(test.overflow),
+			)
+
+			for i := 0; i < 5; i++ {
+				i := i
+				d.Do(func() {
+					executed = append(executed, i)
+					at = append(at, clock.Now().Sub(start))
+				})
+			}
+
+			clock.Advance(time.Second)
+
+			if !reflect.DeepEqual(executed, test.expected) {
+				t.Fatalf("Expected %v, got %v", test.expected, executed)
+			}
+			for i, a := range at {
+				if expected := time.Duration(i) * 100 * time.Millisecond; a != expected {
+					t.Errorf("Expected execution %d at %s, got %s", i, expected, a)
+				}
+			}
+		})
+	}
+}
+
+func TestLeakyBucketDropNotCounted(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []int
+
+	debounced := debounce.NewWithOutcome(
+		time.Hour,
+		debounce.WithClock(clock),
+		debounce.WithMaxCalls(1),
+		debounce.WithMaxFires(4),
+		debounce.WithLeakyBucket(2, 100*time.Millisecond),
+	)
+
+	var outcomes []debounce.CallOutcome
+	for i := 0; i < 6; i++ {
+		i := i
+		outcomes = append(outcomes, debounced(func() {
+			executed = append(executed, i)
+		}))
+		if i == 4 {
+			clock.Advance(time.Second)
+		}
+	}
+	clock.Advance(time.Second)
+
+	expectedOutcomes := []debounce.CallOutcome{
+		debounce.FiredLimit, debounce.Deferred, debounce.Deferred, debounce.Ignored, debounce.Ignored, debounce.FiredLimit,
+	}
+	if !reflect.DeepEqual(outcomes, expectedOutcomes) {
+		t.Errorf("Expected %v, got %v", expectedOutcomes, outcomes)
+	}
+	// The dropped executions don't count towards WithMaxFires.
+	if expected := []int{0, 1, 2, 5}; !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+}
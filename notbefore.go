@@ -0,0 +1,18 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewWithNotBefore is like New, but each call also takes an earliest
+// execution time. The pending function is executed when the debounced
+// function stops being called for the given duration, but never before the
+// latest notBefore seen in the current burst.
+func NewWithNotBefore(after time.Duration, opts ...Option) func(f func(), notBefore time.Time) {
+	d := newDebouncer(after, opts)
+
+	return func(f func(), notBefore time.Time) {
+		d.addNotBefore(f, notBefore)
+	}
+}
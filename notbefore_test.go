@@ -0,0 +1,39 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestNotBefore(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.NewWithNotBefore(50*time.Millisecond, debounce.WithClock(clock))
+
+	debounced(f, clock.Now().Add(300*time.Millisecond))
+	debounced(f, time.Time{})
+
+	clock.Advance(150 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	clock.Advance(300 * time.Millisecond)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
@@ -0,0 +1,691 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// Option configures a Debouncer.
+type Option struct {
+	// kind identifies what the option configures,
+	// so a later option of the same kind overrides an earlier one.
+	kind  string
+	apply func(*config)
+}
+
+type config struct {
+	name           string
+	clock          clock
+	maxCalls       int
+	callWindow     time.Duration
+	maxWait        time.Duration
+	stalenessBound time.Duration
+	burstGap       time.Duration
+	initialDelay   time.Duration
+	dynamicAfter   func(now time.Time) time.Duration
+	onAbandon      func()
+	preCheck       func() bool
+	maxFires       int
+	onMaxFires     func()
+	closeMode      CloseMode
+	fallback       func()
+	fallbackIdle   time.Duration
+	dropLog        int
+
+	stallThreshold time.Duration
+	onStall        func(deadline time.Time)
+	onFireTiming   func(scheduled, actual time.Time)
+	onExecute      func(name string, t time.Time)
+	onDrop         func(f func())
+	panicHandler   func(p Panic)
+	newSpan        func() Span
+	executor       func(f func())
+
+	leakCapacity int
+	leakRate     time.Duration
+	leakOverflow LeakOverflow
+
+	backoffFactor float64
+	backoffMax    time.Duration
+	jitter        time.Duration
+	errBuffer     int
+
+	latencyTracking      bool
+	queueDuringExecution bool
+	dedupTags            bool
+	leading              bool
+	noTrailing           bool
+	cascadeCancel        bool
+	orderedAll           bool
+	collect              bool
+	throttle             bool
+	keepFirst            bool
+	accumulate           bool
+	stopOnCollect        bool
+	register             bool
+	executionTracker     bool
+
+	maxQueued   int
+	queuePolicy QueuePolicy
+
+	zeroValueMode  ZeroValueMode
+	commitInterval time.Duration
+
+	// A func(T) uint64, see WithValueHasher.
+	valueHasher any
+}
+
+// WithName sets a name for the Debouncer, e.g. to tell debouncers apart in
+// logs and metrics. It's passed to the hooks and set in Stats and FireInfo.
+func WithName(name string) Option {
+	return Option{
+		kind: "name",
+		apply: func(c *config) {
+			c.name = name
+		},
+	}
+}
+
+// WithMaxCalls executes the pending function immediately when it has been
+// scheduled n times in the current burst. A n <= 0 means no limit.
+// The execution happens on the calling goroutine, so with n = 1, every
+// function is executed right away, before the call returns.
+func WithMaxCalls(n int) Option {
+	return Option{
+		kind: "maxCalls",
+		apply: func(c *config) {
+			c.maxCalls = max(n, 0)
+		},
+	}
+}
+
+// WithCallWindow makes WithMaxCalls only count the calls made within d of
+// the first counted one. When d has passed without reaching the limit, the
+// count starts over with the next call, so sparse calls in a long burst
+// don't add up to the limit. A d <= 0 counts all the calls in the burst.
+func WithCallWindow(d time.Duration) Option {
+	return Option{
+		kind: "callWindow",
+		apply: func(c *config) {
+			c.callWindow = d
+		},
+	}
+}
+
+// WithMaxWait executes the pending function at the latest d after the first
+// call in the current burst, even if the calls never stop.
+// A d <= 0 means no limit.
+func WithMaxWait(d time.Duration) Option {
+	return Option{
+		kind: "maxWait",
+		apply: func(c *config) {
+			c.maxWait = max(d, 0)
+		},
+	}
+}
+
+// WithStalenessBound executes the pending function at the latest d after the
+// previous execution, or after the first call if nothing has been executed
+// yet, even if the calls never stop. Unlike WithMaxWait, the bound is not
+// measured from the first call in the current burst, so it caps how stale
+// the result of the last execution can get. A d <= 0 means no limit.
+func WithStalenessBound(d time.Duration) Option {
+	return Option{
+		kind: "stalenessBound",
+		apply: func(c *config) {
+			c.stalenessBound = d
+		},
+	}
+}
+
+// WithInitialDelay makes the pending function wait at least d after the
+// first call in each burst, i.e. after the previous execution, before it's
+// executed. The following calls in the burst use the configured duration as
+// usual. The max wait window, see WithMaxWait, starts when the initial delay
+// has passed. It doesn't apply to executions on the leading edge.
+func WithInitialDelay(d time.Duration) Option {
+	return Option{
+		kind: "initialDelay",
+		apply: func(c *config) {
+			c.initialDelay = d
+		},
+	}
+}
+
+// WithKeepFirst makes the first function passed in a burst win, instead of
+// the last one. The later calls still count, e.g. to delay the execution.
+func WithKeepFirst(enabled bool) Option {
+	return Option{
+		kind: "keepFirst",
+		apply: func(c *config) {
+			c.keepFirst = enabled
+		},
+	}
+}
+
+// WithTimeout is the same as WithMaxWait: the pending function is executed
+// at the latest d after the first call in the current burst, and the burst
+// starts over. Both configure the same setting, so the last one wins.
+func WithTimeout(d time.Duration) Option {
+	return WithMaxWait(d)
+}
+
+// WithLeading makes a call that starts a new burst execute its function right
+// away, on the leading edge. The last function passed during the burst is
+// executed on the trailing edge when the calls stop for the given duration,
+// if there were any calls after the first one. An execution triggered by
+// e.g. WithMaxCalls or WithMaxWait doesn't end the burst, so the next call
+// isn't executed on the leading edge. A leading execution never starts
+// before the executions of earlier bursts have completed.
+func WithLeading(enabled bool) Option {
+	return Option{
+		kind: "leading",
+		apply: func(c *config) {
+			c.leading = enabled
+		},
+	}
+}
+
+// WithTrailing controls whether the pending function is executed on the
+// trailing edge, when the calls stop for the given duration. It's enabled by
+// default. When disabled, the pending function is discarded instead, so
+// combined with WithLeading, only the first call in each burst is executed.
+// Without WithLeading, only executions triggered by e.g. WithMaxCalls,
+// WithMaxWait or Flush remain.
+func WithTrailing(enabled bool) Option {
+	return Option{
+		kind: "trailing",
+		apply: func(c *config) {
+			c.noTrailing = !enabled
+		},
+	}
+}
+
+// WithPreCheck sets a function that is evaluated when a call would start a
+// new burst. If it returns false, the call is dropped and no burst is
+// started. Calls within a burst are not checked.
+// The function must not call back into the Debouncer.
+func WithPreCheck(f func() bool) Option {
+	return Option{
+		kind: "preCheck",
+		apply: func(c *config) {
+			c.preCheck = f
+		},
+	}
+}
+
+// WithDynamicAfter sets a function that computes the duration to wait from
+// the current time, e.g. to debounce less aggressively during business hours.
+// It is evaluated every time the timer is (re)armed, and replaces the
+// duration passed to the constructor.
+func WithDynamicAfter(f func(now time.Time) time.Duration) Option {
+	return Option{
+		kind: "dynamicAfter",
+		apply: func(c *config) {
+			c.dynamicAfter = f
+		},
+	}
+}
+
+// WithOnAbandon sets a function that is called when a pending function is
+// discarded by Cancel, e.g. to release resources tied to the abandoned work.
+// It is called once per abandoned burst, not once per superseded function.
+func WithOnAbandon(f func()) Option {
+	return Option{
+		kind: "onAbandon",
+		apply: func(c *config) {
+			c.onAbandon = f
+		},
+	}
+}
+
+// WithLatencyTracking enables tracking of the time from the first call in a
+// burst to its execution, reported in Stats.
+func WithLatencyTracking(enabled bool) Option {
+	return Option{
+		kind: "latencyTracking",
+		apply: func(c *config) {
+			c.latencyTracking = enabled
+		},
+	}
+}
+
+// WithQueueDuringExecution makes calls that arrive while the function is
+// executing start a new burst that is executed when the current execution
+// has completed, or when the duration has passed, whichever comes last.
+// This also means that executions never overlap.
+func WithQueueDuringExecution(enabled bool) Option {
+	return Option{
+		kind: "queueDuringExecution",
+		apply: func(c *config) {
+			c.queueDuringExecution = enabled
+		},
+	}
+}
+
+// WithMaxFires makes the Debouncer stop accepting new calls after n
+// executions. Pending work is discarded when the limit is reached.
+// A n <= 0 means no limit.
+func WithMaxFires(n int) Option {
+	return Option{
+		kind: "maxFires",
+		apply: func(c *config) {
+			c.maxFires = n
+		},
+	}
+}
+
+// WithOnMaxFires sets a function that is called when the last execution
+// allowed by WithMaxFires has completed. It runs as part of that execution,
+// see Close.
+func WithOnMaxFires(f func()) Option {
+	return Option{
+		kind: "onMaxFires",
+		apply: func(c *config) {
+			c.onMaxFires = f
+		},
+	}
+}
+
+// WithErrorBackoff makes the duration to wait grow by factor for every
+// consecutive failed execution of a function passed to DoErr, up to max.
+// The duration is back to normal after a successful execution.
+func WithErrorBackoff(factor float64, max time.Duration) Option {
+	return Option{
+		kind: "errorBackoff",
+		apply: func(c *config) {
+			c.backoffFactor = factor
+			c.backoffMax = max
+		},
+	}
+}
+
+// WithJitter adds a random duration in [0, max) to the duration to wait,
+// drawn anew every time the timer is armed, e.g. to keep the debouncers of
+// instances started together from executing in lockstep. WithMaxWait still
+// bounds the wait.
+func WithJitter(max time.Duration) Option {
+	return Option{
+		kind: "jitter",
+		apply: func(c *config) {
+			c.jitter = max
+		},
+	}
+}
+
+// WithDedupTags makes NewTagged pass each tag once per burst.
+func WithDedupTags(enabled bool) Option {
+	return Option{
+		kind: "dedupTags",
+		apply: func(c *config) {
+			c.dedupTags = enabled
+		},
+	}
+}
+
+// WithCascadeCancel makes Cancel also cancel the pending work of the
+// downstream Debouncers wired with Chain, so no stale downstream work is
+// executed after the upstream has been cancelled.
+func WithCascadeCancel(enabled bool) Option {
+	return Option{
+		kind: "cascadeCancel",
+		apply: func(c *config) {
+			c.cascadeCancel = enabled
+		},
+	}
+}
+
+// WithCloseMode sets what Close does with the pending function.
+// The default is CloseFlushSync.
+func WithCloseMode(mode CloseMode) Option {
+	return Option{
+		kind: "closeMode",
+		apply: func(c *config) {
+			c.closeMode = mode
+		},
+	}
+}
+
+// WithFallback sets a function that is executed once when the Debouncer has
+// been idle, with no calls and no pending work, for the given duration,
+// e.g. a heartbeat. Any call restarts the idle period.
+func WithFallback(f func(), idle time.Duration) Option {
+	return Option{
+		kind: "fallback",
+		apply: func(c *config) {
+			c.fallback = f
+			c.fallbackIdle = idle
+		},
+	}
+}
+
+// WithDropLog keeps the n most recent pending functions discarded by Cancel
+// or Close, available from DroppedFuncs, so they can be replayed later.
+func WithDropLog(n int) Option {
+	return Option{
+		kind: "dropLog",
+		apply: func(c *config) {
+			c.dropLog = n
+		},
+	}
+}
+
+// WithLeakyBucket smooths the executions into a steady stream: at most one
+// execution is released every leakRate, and up to capacity executions can
+// wait in the bucket. What happens to executions beyond the capacity is
+// controlled by WithLeakOverflow. Flush and Close bypass the bucket.
+func WithLeakyBucket(capacity int, leakRate time.Duration) Option {
+	return Option{
+		kind: "leakyBucket",
+		apply: func(c *config) {
+			c.leakCapacity = capacity
+			c.leakRate = leakRate
+		},
+	}
+}
+
+// WithLeakOverflow sets what happens to executions that don't fit
+// in the bucket set up by WithLeakyBucket. The default is LeakDrop.
+func WithLeakOverflow(policy LeakOverflow) Option {
+	return Option{
+		kind: "leakOverflow",
+		apply: func(c *config) {
+			c.leakOverflow = policy
+		},
+	}
+}
+
+// WithOrderedAll makes every function passed to the Debouncer execute,
+// instead of only the last one. The functions are queued and executed in
+// submission order, one every duration, starting one duration after the
+// first call. Flush and Close execute all of the queued functions.
+// Options controlling when the pending function is executed, e.g.
+// WithLeading and WithMaxCalls, don't apply. See WithMaxQueued.
+func WithOrderedAll(enabled bool) Option {
+	return Option{
+		kind: "orderedAll",
+		apply: func(c *config) {
+			c.orderedAll = enabled
+		},
+	}
+}
+
+// WithAccumulateFuncs makes every distinct function passed during a burst
+// execute, in the order they were first passed, instead of only the last
+// one. Functions are told apart by their code pointer, see
+// reflect.Value.Pointer, so passing the same function again is a no-op, and
+// so is passing another closure created by the same function literal or
+// another method value of the same method. See WithMaxQueued.
+func WithAccumulateFuncs(enabled bool) Option {
+	return Option{
+		kind: "accumulateFuncs",
+		apply: func(c *config) {
+			c.accumulate = enabled
+		},
+	}
+}
+
+// WithMaxQueued bounds the number of functions queued by WithOrderedAll,
+// WithAccumulateFuncs and NewCollecting to n, using policy to decide what
+// happens when the queue is full. A n <= 0 means no limit.
+func WithMaxQueued(n int, policy QueuePolicy) Option {
+	return Option{
+		kind: "maxQueued",
+		apply: func(c *config) {
+			c.maxQueued = n
+			c.queuePolicy = policy
+		},
+	}
+}
+
+// WithQueue is WithOrderedAll with the queue bounded to maxLen functions,
+// discarding the oldest when it's full, see WithMaxQueued. The discarded
+// functions are counted in Stats.
+func WithQueue(maxLen int) Option {
+	return Option{
+		kind: "queue",
+		apply: func(c *config) {
+			c.orderedAll = true
+			c.maxQueued = maxLen
+			c.queuePolicy = DropOldest
+		},
+	}
+}
+
+// WithRegister adds the Debouncer to a package level registry used by
+// FlushAllRegistered and CancelAllRegistered. It's removed from the
+// registry when closed.
+func WithRegister(enabled bool) Option {
+	return Option{
+		kind: "register",
+		apply: func(c *config) {
+			c.register = enabled
+		},
+	}
+}
+
+// WithStallDetector sets a function that is called if the pending function
+// hasn't started executing threshold after the time it was scheduled for,
+// e.g. because a slow execution, or the executor set with WithExecutor, is
+// blocking it. The function receives the missed deadline.
+func WithStallDetector(threshold time.Duration, onStall func(deadline time.Time)) Option {
+	return Option{
+		kind: "stallDetector",
+		apply: func(c *config) {
+			c.stallThreshold = threshold
+			c.onStall = onStall
+		},
+	}
+}
+
+// WithBurstGap splits a burst in two when two consecutive calls are more
+// than gap apart: the pending function of the first burst is executed
+// before the second burst starts. A gap <= 0 means no splitting.
+func WithBurstGap(gap time.Duration) Option {
+	return Option{
+		kind: "burstGap",
+		apply: func(c *config) {
+			c.burstGap = gap
+		},
+	}
+}
+
+// WithOnFireTiming sets a function that is called right before each
+// execution with the time the execution was scheduled for and the actual
+// time, e.g. to measure timer jitter. Executions that are not driven by the
+// timer, e.g. on reaching WithMaxCalls or on Flush, are scheduled for the
+// time they were triggered.
+func WithOnFireTiming(onFireTiming func(scheduled, actual time.Time)) Option {
+	return Option{
+		kind: "onFireTiming",
+		apply: func(c *config) {
+			c.onFireTiming = onFireTiming
+		},
+	}
+}
+
+// WithOnExecute sets a function that is called once per execution with the
+// name of the Debouncer, see WithName, and the time the execution started,
+// e.g. for audit logging. It is called on the executing goroutine after the
+// function returns, so a slow hook delays only the next execution, not the
+// calls scheduling it. It is not called if the function panics.
+func WithOnExecute(onExecute func(name string, t time.Time)) Option {
+	return Option{
+		kind: "onExecute",
+		apply: func(c *config) {
+			c.onExecute = onExecute
+		},
+	}
+}
+
+// WithExecutor makes the executions driven by a timer hand f off to
+// executor, e.g. a worker pool, instead of running it on the timer's
+// goroutine. The executor must eventually run f: until it has completed,
+// the execution counts as in flight, e.g. for WithQueueDuringExecution and
+// Close. Executions triggered by a call, e.g. by WithMaxCalls, or by Flush
+// run on the calling goroutine as usual.
+func WithExecutor(executor func(f func())) Option {
+	return Option{
+		kind: "executor",
+		apply: func(c *config) {
+			c.executor = executor
+		},
+	}
+}
+
+// WithStopOnCollect makes the function returned by New discard its pending
+// function and stop its timer when the function is garbage collected, so an
+// abandoned debounced function with a long duration doesn't keep its state
+// alive until the timer fires. It only applies to New.
+func WithStopOnCollect(enabled bool) Option {
+	return Option{
+		kind: "stopOnCollect",
+		apply: func(c *config) {
+			c.stopOnCollect = enabled
+		},
+	}
+}
+
+// WithOnDrop sets a function that is called with every function that won't
+// be executed because another call superseded it, e.g. to log or account
+// for the dropped work. With WithKeepFirst, that's the function passed by
+// the later call. It's called on the calling goroutine, after the
+// Debouncer's lock has been released. See WithDropLog for the functions
+// discarded by Cancel or Close.
+func WithOnDrop(onDrop func(f func())) Option {
+	return Option{
+		kind: "onDrop",
+		apply: func(c *config) {
+			c.onDrop = onDrop
+		},
+	}
+}
+
+// WithPanicHandler sets a function that is called with the panics recovered
+// from the executed functions. Functions executed together, e.g. by
+// NewCollecting, are isolated from each other: all of them are executed, in
+// order, even if one panics. Without a handler, the first panic is
+// re-raised after all of them have been executed.
+func WithPanicHandler(handler func(p Panic)) Option {
+	return Option{
+		kind: "panicHandler",
+		apply: func(c *config) {
+			c.panicHandler = handler
+		},
+	}
+}
+
+// WithZeroValueMode sets what happens to the functions passed to a
+// Debouncer with a zero duration, e.g. a zero value Debouncer.
+// The default is DefaultZeroValueMode.
+func WithZeroValueMode(mode ZeroValueMode) Option {
+	return Option{
+		kind: "zeroValueMode",
+		apply: func(c *config) {
+			c.zeroValueMode = mode
+		},
+	}
+}
+
+// WithExecutionTracker makes the Debouncer track whether its executions
+// ever overlap, see ConcurrentExecutionsSeen. It's meant for debugging.
+func WithExecutionTracker(enabled bool) Option {
+	return Option{
+		kind: "executionTracker",
+		apply: func(c *config) {
+			c.executionTracker = enabled
+		},
+	}
+}
+
+// WithSpanFactory sets a function that creates a Span for each execution,
+// e.g. an OpenTelemetry span. The span describes the burst executed and is
+// ended when the execution completes.
+func WithSpanFactory(newSpan func() Span) Option {
+	return Option{
+		kind: "spanFactory",
+		apply: func(c *config) {
+			c.newSpan = newSpan
+		},
+	}
+}
+
+// WithCommitInterval executes the pending function once at the end of each
+// interval, aligned to the zero time, in which there were calls, no matter
+// how the calls are distributed. The configured duration and options
+// controlling bursts, e.g. WithLeading and WithMaxCalls, don't apply.
+// A interval <= 0 means no commit interval.
+func WithCommitInterval(interval time.Duration) Option {
+	return Option{
+		kind: "commitInterval",
+		apply: func(c *config) {
+			c.commitInterval = interval
+		},
+	}
+}
+
+// WithRecover is like WithPanicHandler, but handler only receives the name
+// of the Debouncer, see WithName, and the recovered value. Without a
+// handler, a panic in an executed function crashes the program, as any panic
+// on a timer goroutine does.
+func WithRecover(handler func(name string, v any)) Option {
+	if handler == nil {
+		return WithPanicHandler(nil)
+	}
+	return WithPanicHandler(func(p Panic) {
+		handler(p.Name, p.Value)
+	})
+}
+
+// WithErrBuffer sets the size of the buffer of the error channel returned
+// by NewErr. The default is 1.
+func WithErrBuffer(n int) Option {
+	return Option{
+		kind: "errBuffer",
+		apply: func(c *config) {
+			c.errBuffer = n
+		},
+	}
+}
+
+// WithValueHasher sets a function hashing the value passed to a debounced
+// function returned by NewArg or NewArgWithControls, so that PendingKey also
+// identifies the pending value. T must be the type of the values, or hasher
+// is ignored.
+func WithValueHasher[T any](hasher func(v T) uint64) Option {
+	return Option{
+		kind: "valueHasher",
+		apply: func(c *config) {
+			c.valueHasher = hasher
+		},
+	}
+}
+
+// MergeOptions flattens the given option lists into one, where an option in a
+// later layer replaces an option of the same kind in an earlier layer, e.g.
+// defaults, then profile, then per-instance options. The options are
+// applied in the order they were last set, so an option setting several
+// kinds, e.g. WithQueue, doesn't override a later layer.
+func MergeOptions(layers ...[]Option) []Option {
+	var merged []Option
+	index := make(map[string]int)
+	for _, layer := range layers {
+		for _, opt := range layer {
+			if i, found := index[opt.kind]; found {
+				// Moved to the end, see above.
+				merged[i] = Option{}
+			}
+			index[opt.kind] = len(merged)
+			merged = append(merged, opt)
+		}
+	}
+
+	n := 0
+	for _, opt := range merged {
+		if opt.apply != nil {
+			merged[n] = opt
+			n++
+		}
+	}
+	return merged[:n]
+}
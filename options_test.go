@@ -0,0 +1,1036 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestMaxCalls(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	debounced := debounce.New(time.Hour, debounce.WithMaxCalls(3))
+
+	for i := 0; i < 7; i++ {
+		debounced(f)
+	}
+
+	c := int(atomic.LoadUint64(&counter))
+	if c != 2 {
+		t.Error("Expected count 2, was", c)
+	}
+}
+
+func TestMaxCallsBoundaries(t *testing.T) {
+	for _, test := range []struct {
+		maxCalls int
+		// The count after each of the 3 calls, and after the calls stop.
+		expected []int
+	}{
+		{0, []int{0, 0, 0, 1}},
+		{1, []int{1, 2, 3, 3}},
+		{2, []int{0, 1, 1, 2}},
+	} {
+		t.Run(fmt.Sprint(test.maxCalls), func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+
+			var (
+				counter int
+				counts  []int
+			)
+
+			debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithMaxCalls(test.maxCalls))
+
+			for i := 0; i < 3; i++ {
+				debounced(func() { counter++ })
+				counts = append(counts, counter)
+			}
+
+			clock.Advance(100 * time.Millisecond)
+			counts = append(counts, counter)
+
+			if fmt.Sprint(counts) != fmt.Sprint(test.expected) {
+				t.Errorf("Expected counts %v, got %v", test.expected, counts)
+			}
+		})
+	}
+}
+
+func TestCallWindow(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var counter int
+
+	f := func() {
+		counter++
+	}
+
+	debounced := debounce.New(time.Hour, debounce.WithClock(clock), debounce.WithMaxCalls(3), debounce.WithCallWindow(time.Second))
+
+	debounced(f)
+	debounced(f)
+	clock.Advance(2 * time.Second)
+	debounced(f)
+	debounced(f)
+
+	if counter != 0 {
+		t.Fatal("Expected count 0, was", counter)
+	}
+
+	// The third call in the current window.
+	clock.Advance(100 * time.Millisecond)
+	debounced(f)
+
+	if counter != 1 {
+		t.Error("Expected count 1, was", counter)
+	}
+}
+
+func TestMaxCallsConcurrent(t *testing.T) {
+	var (
+		counter uint64
+		wg      sync.WaitGroup
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	d := debounce.NewDebouncer(time.Hour, debounce.WithMaxCalls(5))
+
+	const total = 1003
+
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Do(f)
+		}()
+	}
+	wg.Wait()
+
+	if c := int(atomic.LoadUint64(&counter)); c != total/5 {
+		t.Errorf("Expected count %d, was %d", total/5, c)
+	}
+	if c := d.PeekCalls(); c != total%5 {
+		t.Errorf("Expected %d calls pending, got %d", total%5, c)
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	opts := debounce.MergeOptions(
+		[]debounce.Option{debounce.WithMaxCalls(5)},
+		[]debounce.Option{debounce.WithMaxCalls(10)},
+	)
+	if len(opts) != 1 {
+		t.Fatal("Expected 1 option, got", len(opts))
+	}
+
+	debounced := debounce.New(time.Hour, opts...)
+
+	for i := 0; i < 9; i++ {
+		debounced(f)
+	}
+
+	if c := int(atomic.LoadUint64(&counter)); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	debounced(f)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestMergeOptionsLaterLayerWins(t *testing.T) {
+	opts := debounce.MergeOptions(
+		[]debounce.Option{debounce.WithMaxQueued(3, debounce.DropNewest), debounce.WithQueue(5)},
+		[]debounce.Option{debounce.WithMaxQueued(10, debounce.ForceFlush)},
+	)
+
+	d := debounce.NewDebouncer(time.Hour, opts...)
+	defer d.Cancel()
+
+	for i := 0; i < 7; i++ {
+		d.Do(func() {})
+	}
+
+	if s := d.Stats(); s.QueueDropped != 0 || s.Executed != 0 {
+		t.Errorf("Expected the queue bound of the later layer, got %d dropped, %d executed", s.QueueDropped, s.Executed)
+	}
+	if c := d.PeekCalls(); c != 7 {
+		t.Error("Expected 7 queued calls, got", c)
+	}
+}
+
+func TestDynamicAfter(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	debounced := debounce.New(
+		time.Hour,
+		debounce.WithClock(clock),
+		debounce.WithDynamicAfter(func(now time.Time) time.Duration {
+			if now.Hour() < 18 {
+				return 100 * time.Millisecond
+			}
+			return time.Second
+		}),
+	)
+
+	debounced(f)
+	clock.Advance(99 * time.Millisecond)
+	if c := int(atomic.LoadUint64(&counter)); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+	clock.Advance(time.Millisecond)
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Fatal("Expected count 1, was", c)
+	}
+
+	clock.Advance(10 * time.Hour)
+
+	debounced(f)
+	clock.Advance(999 * time.Millisecond)
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Fatal("Expected count 1, was", c)
+	}
+	clock.Advance(time.Millisecond)
+	if c := int(atomic.LoadUint64(&counter)); c != 2 {
+		t.Fatal("Expected count 2, was", c)
+	}
+}
+
+func TestQueueDuringExecution(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+
+	record := func(s string) {
+		mu.Lock()
+		events = append(events, s)
+		mu.Unlock()
+	}
+
+	debounced := debounce.New(20*time.Millisecond, debounce.WithQueueDuringExecution(true))
+
+	debounced(func() {
+		record("first start")
+		time.Sleep(100 * time.Millisecond)
+		record("first done")
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	debounced(func() {
+		record("second")
+	})
+
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []string{"first start", "first done", "second"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, events)
+	}
+	for i, e := range expected {
+		if events[i] != e {
+			t.Fatalf("Expected %v, got %v", expected, events)
+		}
+	}
+}
+
+func TestMaxFires(t *testing.T) {
+	var (
+		counter  uint64
+		maxFires uint64
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.New(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithMaxFires(3),
+		debounce.WithOnMaxFires(func() {
+			atomic.AddUint64(&maxFires, 1)
+		}),
+	)
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 10; j++ {
+			debounced(f)
+		}
+		clock.Advance(200 * time.Millisecond)
+	}
+
+	if c := int(atomic.LoadUint64(&counter)); c != 3 {
+		t.Error("Expected count 3, was", c)
+	}
+	if c := int(atomic.LoadUint64(&maxFires)); c != 1 {
+		t.Error("Expected OnMaxFires to be called once, was", c)
+	}
+}
+
+func TestPreCheck(t *testing.T) {
+	var (
+		counter uint64
+		checks  uint64
+		allow   atomic.Bool
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.NewWithOutcome(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithPreCheck(func() bool {
+			atomic.AddUint64(&checks, 1)
+			return allow.Load()
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		if outcome := debounced(f); outcome != debounce.Ignored {
+			t.Fatal("Expected Ignored, got", outcome)
+		}
+	}
+	clock.Advance(time.Second)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	allow.Store(true)
+
+	for i := 0; i < 3; i++ {
+		if outcome := debounced(f); outcome != debounce.Deferred {
+			t.Fatal("Expected Deferred, got", outcome)
+		}
+	}
+	clock.Advance(time.Second)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+
+	// Only the calls starting a burst are checked.
+	if c := int(atomic.LoadUint64(&checks)); c != 4 {
+		t.Error("Expected 4 checks, was", c)
+	}
+}
+
+func TestBurstGap(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var fired []int
+
+	debounced := debounce.New(100*time.Millisecond, debounce.WithBurstGap(30*time.Millisecond), debounce.WithClock(clock))
+
+	call := func(i int) {
+		debounced(func() { fired = append(fired, i) })
+	}
+
+	// Two bursts, the calls in each spaced below the gap.
+	call(1)
+	clock.Advance(20 * time.Millisecond)
+	call(2)
+	clock.Advance(50 * time.Millisecond)
+	call(3)
+	clock.Advance(20 * time.Millisecond)
+	call(4)
+
+	if len(fired) != 1 || fired[0] != 2 {
+		t.Fatal("Expected the first burst to fire, got", fired)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(fired) != 2 || fired[1] != 4 {
+		t.Error("Expected the second burst to fire, got", fired)
+	}
+}
+
+func TestOnFireTiming(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		scheduled, actual time.Time
+	)
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithOnFireTiming(func(s, a time.Time) {
+		mu.Lock()
+		scheduled, actual = s, a
+		mu.Unlock()
+	}))
+
+	start := time.Now()
+	debounced(func() {})
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if d := scheduled.Sub(start.Add(50 * time.Millisecond)); d < 0 || d > 10*time.Millisecond {
+		t.Error("Expected scheduled near first call + after, off by", d)
+	}
+	if d := actual.Sub(scheduled); d < 0 || d > 40*time.Millisecond {
+		t.Error("Expected actual near scheduled, off by", d)
+	}
+}
+
+func TestOnExecute(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var executed []time.Duration
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithOnExecute(func(_ string, t time.Time) {
+		executed = append(executed, t.Sub(start))
+	}))
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			debounced(func() {})
+			clock.Advance(10 * time.Millisecond)
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	if expected := []time.Duration{90 * time.Millisecond, 240 * time.Millisecond, 390 * time.Millisecond}; fmt.Sprint(executed) != fmt.Sprint(expected) {
+		t.Errorf("Expected one call per execution %v, got %v", expected, executed)
+	}
+}
+
+func TestLeadingAfterTrailing(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		active   int
+		lastSeq  uint64
+		failures []string
+		wg       sync.WaitGroup
+	)
+
+	debounced := debounce.NewWithInfo(time.Millisecond, debounce.WithLeading(true))
+
+	f := func(info debounce.FireInfo) {
+		mu.Lock()
+		if info.Seq < lastSeq {
+			failures = append(failures, fmt.Sprintf("execution %d started after %d", info.Seq, lastSeq))
+		}
+		if info.Reason == debounce.ReasonLeading && active > 0 {
+			failures = append(failures, fmt.Sprintf("leading execution %d overlapped", info.Seq))
+		}
+		lastSeq = info.Seq
+		active++
+		mu.Unlock()
+
+		time.Sleep(time.Duration(info.Seq%3) * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				debounced(f)
+				time.Sleep(time.Duration(j%4) * 500 * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(failures) > 0 {
+		t.Errorf("Expected trailing executions to complete before the next leading one, got %d failures, first: %s", len(failures), failures[0])
+	}
+}
+
+func TestCommitInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := debounce.NewFakeClock(start)
+
+	var fired []time.Duration
+
+	d := debounce.NewDebouncer(
+		time.Hour,
+		debounce.WithClock(clock),
+		debounce.WithCommitInterval(100*time.Millisecond),
+	)
+
+	f := func() {
+		fired = append(fired, clock.Now().Sub(start))
+	}
+
+	// Calls at 10, 50, 90, 250, 310 and 399 ms.
+	for _, advance := range []time.Duration{10, 40, 40, 160, 60, 89} {
+		clock.Advance(advance * time.Millisecond)
+		d.Do(f)
+	}
+
+	clock.Advance(time.Second)
+
+	expected := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 400 * time.Millisecond}
+	if len(fired) != len(expected) {
+		t.Fatalf("Expected executions at %v, got %v", expected, fired)
+	}
+	for i, at := range fired {
+		if at != expected[i] {
+			t.Errorf("Expected executions at %v, got %v", expected, fired)
+			break
+		}
+	}
+}
+
+func TestLeading(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     []debounce.Option
+		calls    int
+		interval time.Duration
+		expected []string
+	}{
+		{
+			name:     "single call",
+			calls:    1,
+			expected: []string{"0 at 0s"},
+		},
+		{
+			name:     "burst",
+			calls:    5,
+			interval: 10 * time.Millisecond,
+			expected: []string{"0 at 0s", "4 at 90ms"},
+		},
+		{
+			name:     "max calls",
+			opts:     []debounce.Option{debounce.WithMaxCalls(2)},
+			calls:    5,
+			interval: 10 * time.Millisecond,
+			expected: []string{"0 at 0s", "2 at 20ms", "4 at 40ms"},
+		},
+		{
+			name:     "max wait",
+			opts:     []debounce.Option{debounce.WithMaxWait(25 * time.Millisecond)},
+			calls:    5,
+			interval: 10 * time.Millisecond,
+			expected: []string{"0 at 0s", "3 at 35ms", "4 at 65ms"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+			start := clock.Now()
+
+			var got []string
+
+			debounced := debounce.New(50*time.Millisecond, append(test.opts, debounce.WithLeading(true), debounce.WithClock(clock))...)
+
+			for i := 0; i < test.calls; i++ {
+				i := i
+				debounced(func() {
+					got = append(got, fmt.Sprintf("%d at %s", i, clock.Now().Sub(start)))
+				})
+				clock.Advance(test.interval)
+			}
+
+			clock.Advance(time.Second)
+
+			if fmt.Sprint(got) != fmt.Sprint(test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestLeadingTrailing(t *testing.T) {
+	for _, test := range []struct {
+		leading  bool
+		trailing bool
+		single   []int
+		burst    []int
+	}{
+		{leading: false, trailing: true, single: []int{0}, burst: []int{4}},
+		{leading: true, trailing: true, single: []int{0}, burst: []int{0, 4}},
+		{leading: true, trailing: false, single: []int{0}, burst: []int{0}},
+		{leading: false, trailing: false, single: nil, burst: nil},
+	} {
+		t.Run(fmt.Sprintf("leading=%t/trailing=%t", test.leading, test.trailing), func(t *testing.T) {
+			for _, calls := range []int{1, 5} {
+				clock := debounce.NewFakeClock(time.Now())
+
+				var got []int
+
+				debounced := debounce.New(
+					50*time.Millisecond,
+					debounce.WithClock(clock),
+					debounce.WithLeading(test.leading),
+					debounce.WithTrailing(test.trailing),
+				)
+
+				// Two bursts.
+				for burst := 0; burst < 2; burst++ {
+					got = nil
+					for i := 0; i < calls; i++ {
+						i := i
+						debounced(func() { got = append(got, i) })
+						clock.Advance(10 * time.Millisecond)
+					}
+
+					clock.Advance(time.Second)
+
+					expected := test.burst
+					if calls == 1 {
+						expected = test.single
+					}
+					if fmt.Sprint(got) != fmt.Sprint(expected) {
+						t.Errorf("%d calls, burst %d: expected %v, got %v", calls, burst, expected, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithTimeout(time.Second),
+	)
+
+	f := func() {
+		fired = append(fired, clock.Now().Sub(start))
+	}
+
+	// Calls every 50ms, so the calls never stop.
+	for i := 0; i < 50; i++ {
+		d.Do(f)
+		clock.Advance(50 * time.Millisecond)
+
+		if i == 20 {
+			// Right after the first timeout, a new burst has started.
+			_, calls, startWait := debounce.BurstState(d)
+			if calls != 1 || startWait.Sub(start) != time.Second {
+				t.Fatalf("Expected a new burst at 1s with 1 call, got %d calls from %s", calls, startWait.Sub(start))
+			}
+		}
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second}
+	if fmt.Sprint(fired) != fmt.Sprint(expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, fired)
+	}
+}
+
+func TestMaxWaitProgress(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var (
+		fired []time.Duration
+		total int
+	)
+
+	debounced := debounce.NewWithCount(time.Second, debounce.WithClock(clock), debounce.WithMaxWait(time.Second))
+
+	// Every call arrives before the previous one's duration has passed, so
+	// without a max wait, nothing would be executed until the calls stop.
+	for i := 0; i < 10; i++ {
+		debounced(func(calls int) {
+			fired = append(fired, clock.Now().Sub(start))
+			total += calls
+		})
+		clock.Advance(900 * time.Millisecond)
+	}
+
+	// Every second call overruns the max wait of its burst.
+	expected := []time.Duration{
+		1000 * time.Millisecond,
+		2800 * time.Millisecond,
+		4600 * time.Millisecond,
+		6400 * time.Millisecond,
+		8200 * time.Millisecond,
+	}
+	if fmt.Sprint(fired) != fmt.Sprint(expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, fired)
+	}
+	if total != 10 {
+		t.Error("Expected all 10 calls to be executed, got", total)
+	}
+}
+
+func TestMaxWaitShorterThanAfter(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	debounced := debounce.New(time.Second, debounce.WithClock(clock), debounce.WithMaxWait(100*time.Millisecond))
+
+	// A single call is armed for the max wait, not for the duration.
+	debounced(func() {
+		fired = append(fired, clock.Now().Sub(start))
+	})
+
+	clock.Advance(2 * time.Second)
+
+	if expected := []time.Duration{100 * time.Millisecond}; fmt.Sprint(fired) != fmt.Sprint(expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, fired)
+	}
+}
+
+func TestMaxWaitSpacing(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithMaxWait(200*time.Millisecond))
+
+	// A steady stream of calls, never quiet for long enough.
+	for i := 0; i < 100; i++ {
+		debounced(func() {
+			fired = append(fired, clock.Now().Sub(start))
+		})
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	if len(fired) != 5 {
+		t.Fatal("Expected 5 executions, got", fired)
+	}
+
+	// Each window starts with the first call after the previous execution.
+	for i := 1; i < len(fired); i++ {
+		if gap := fired[i] - fired[i-1]; gap < 200*time.Millisecond || gap > 210*time.Millisecond {
+			t.Errorf("Expected executions spaced by the max wait, got %v", fired)
+			break
+		}
+	}
+}
+
+func TestStalenessBound(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithStalenessBound(200*time.Millisecond))
+
+	f := func() {
+		fired = append(fired, clock.Now().Sub(start))
+	}
+
+	// A steady stream of calls: the first execution is bounded by the first
+	// call, the next ones by the previous execution.
+	for i := 0; i < 45; i++ {
+		debounced(f)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	// Quiet for longer than the bound, so the next call executes right away.
+	clock.Advance(300 * time.Millisecond)
+	debounced(f)
+
+	expected := []time.Duration{
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		490 * time.Millisecond,
+		750 * time.Millisecond,
+	}
+	if fmt.Sprint(fired) != fmt.Sprint(expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, fired)
+	}
+}
+
+func TestInitialDelay(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     []debounce.Option
+		calls    int
+		expected []time.Duration
+	}{
+		{
+			name:     "single call",
+			calls:    1,
+			expected: []time.Duration{200 * time.Millisecond},
+		},
+		{
+			name:     "burst ending after the initial delay",
+			calls:    6,
+			expected: []time.Duration{250 * time.Millisecond},
+		},
+		{
+			// The max wait window starts when the initial delay has passed.
+			name:     "max wait",
+			opts:     []debounce.Option{debounce.WithMaxWait(100 * time.Millisecond)},
+			calls:    16,
+			expected: []time.Duration{300 * time.Millisecond, 620 * time.Millisecond},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+			start := clock.Now()
+
+			var fired []time.Duration
+
+			d := debounce.NewDebouncer(
+				50*time.Millisecond,
+				append(test.opts, debounce.WithClock(clock), debounce.WithInitialDelay(200*time.Millisecond))...,
+			)
+
+			// Calls every 40ms.
+			for i := 0; i < test.calls; i++ {
+				d.Do(func() {
+					fired = append(fired, clock.Now().Sub(start))
+				})
+				clock.Advance(40 * time.Millisecond)
+			}
+
+			clock.Advance(time.Second)
+
+			if fmt.Sprint(fired) != fmt.Sprint(test.expected) {
+				t.Errorf("Expected executions at %v, got %v", test.expected, fired)
+			}
+		})
+	}
+}
+
+func TestKeepFirst(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var executed []string
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithKeepFirst(true))
+
+	for burst := 0; burst < 2; burst++ {
+		for i := 0; i < 3; i++ {
+			i := i
+			debounced(func() {
+				executed = append(executed, fmt.Sprintf("%d at %s", i, clock.Now().Sub(start)))
+			})
+			clock.Advance(10 * time.Millisecond)
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	// The later calls still delay the execution.
+	if expected := []string{"0 at 70ms", "0 at 200ms"}; fmt.Sprint(executed) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+}
+
+func TestName(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var names []string
+
+	d := debounce.NewDebouncer(
+		50*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithName("config"),
+		debounce.WithOnExecute(func(name string, _ time.Time) {
+			names = append(names, "execute: "+name)
+		}),
+		debounce.WithPanicHandler(func(p debounce.Panic) {
+			names = append(names, "panic: "+p.Name)
+		}),
+	)
+
+	d.Do(func() { panic("failed") })
+	clock.Advance(100 * time.Millisecond)
+
+	if s := d.Stats(); s.Name != "config" {
+		t.Error("Expected name config in Stats, got", s.Name)
+	}
+
+	debounced := debounce.NewWithInfo(50*time.Millisecond, debounce.WithClock(clock), debounce.WithName("info"))
+	debounced(func(info debounce.FireInfo) {
+		names = append(names, "info: "+info.Name)
+	})
+	clock.Advance(100 * time.Millisecond)
+
+	// The panic is recovered by the handler, so the hook is called.
+	if expected := "[panic: config execute: config info: info]"; fmt.Sprint(names) != expected {
+		t.Errorf("Expected %s, got %v", expected, names)
+	}
+}
+
+func TestNegativeLimits(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		after    time.Duration
+		opts     []debounce.Option
+		expected int
+	}{
+		// Treated as zero, so disabled.
+		{"After", -time.Second, []debounce.Option{debounce.WithZeroValueMode(debounce.ZeroDisabled)}, 0},
+		// No limit, so executed once when the calls stop.
+		{"MaxWait", 50 * time.Millisecond, []debounce.Option{debounce.WithMaxWait(-time.Second)}, 1},
+		{"MaxCalls", 50 * time.Millisecond, []debounce.Option{debounce.WithMaxCalls(-1)}, 1},
+		{"MaxCallsZero", 50 * time.Millisecond, []debounce.Option{debounce.WithMaxCalls(0)}, 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+
+			var counter int
+
+			debounced := debounce.New(test.after, append(test.opts, debounce.WithClock(clock))...)
+
+			for i := 0; i < 10; i++ {
+				debounced(func() { counter++ })
+				clock.Advance(10 * time.Millisecond)
+			}
+
+			clock.Advance(100 * time.Millisecond)
+
+			if counter != test.expected {
+				t.Errorf("Expected count %d, was %d", test.expected, counter)
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		delays  []time.Duration
+		buckets [10]int
+	)
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithJitter(100*time.Millisecond))
+
+	for i := 0; i < 500; i++ {
+		start := clock.Now()
+		debounced(func() {
+			delays = append(delays, clock.Now().Sub(start))
+		})
+		clock.Advance(200 * time.Millisecond)
+	}
+
+	for _, delay := range delays {
+		if delay < 50*time.Millisecond || delay >= 150*time.Millisecond {
+			t.Fatal("Expected a delay in [50ms, 150ms), got", delay)
+		}
+		buckets[(delay-50*time.Millisecond)/(10*time.Millisecond)]++
+	}
+
+	// With 50 expected per bucket, an empty or crowded one means clustering.
+	for i, n := range buckets {
+		if n < 20 || n > 100 {
+			t.Errorf("Expected the delays to spread across the jitter window, bucket %d has %d", i, n)
+		}
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		handedOff []func()
+		counter   int
+	)
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock), debounce.WithExecutor(func(f func()) {
+		handedOff = append(handedOff, f)
+	}))
+
+	d.Do(func() { counter++ })
+	clock.Advance(100 * time.Millisecond)
+
+	if len(handedOff) != 1 || counter != 0 {
+		t.Fatalf("Expected 1 execution handed off and none run, got %d and %d", len(handedOff), counter)
+	}
+
+	// The next burst starts while the execution is waiting for the executor.
+	d.Do(func() { counter++ })
+	if !d.IsPending() {
+		t.Fatal("Expected a pending function")
+	}
+
+	handedOff[0]()
+	clock.Advance(100 * time.Millisecond)
+
+	if len(handedOff) != 2 {
+		t.Fatal("Expected 2 executions handed off, got", len(handedOff))
+	}
+	handedOff[1]()
+
+	if counter != 2 {
+		t.Error("Expected count 2, was", counter)
+	}
+	if s := d.Stats(); s.Executed != 2 {
+		t.Error("Expected 2 executions, got", s.Executed)
+	}
+}
+
+func TestOnDrop(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed, dropped []int
+
+	debounced := debounce.New(50*time.Millisecond, debounce.WithClock(clock), debounce.WithOnDrop(func(f func()) {
+		before := len(executed)
+		f()
+		dropped = append(dropped, executed[before:]...)
+		executed = executed[:before]
+	}))
+
+	for i := 0; i < 3; i++ {
+		i := i
+		debounced(func() { executed = append(executed, i) })
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if fmt.Sprint(dropped) != "[0 1]" || fmt.Sprint(executed) != "[2]" {
+		t.Errorf("Expected [0 1] dropped and [2] executed, got %v and %v", dropped, executed)
+	}
+}
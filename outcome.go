@@ -0,0 +1,52 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// CallOutcome describes what a call to a debounced function did.
+type CallOutcome int
+
+const (
+	// Deferred means that the function was scheduled for a later execution.
+	Deferred CallOutcome = iota
+
+	// FiredLeading means that the function was executed right away on the
+	// leading edge, see WithLeading.
+	FiredLeading
+
+	// FiredLimit means that the function was executed right away because a
+	// limit, e.g. WithMaxCalls, was reached, or because there was no
+	// duration to wait, see ZeroSync.
+	FiredLimit
+
+	// Ignored means that the call was ignored, e.g. because the limit set by
+	// WithMaxFires was reached or WithPreCheck vetoed the burst.
+	Ignored
+)
+
+func (o CallOutcome) String() string {
+	switch o {
+	case Deferred:
+		return "Deferred"
+	case FiredLeading:
+		return "FiredLeading"
+	case FiredLimit:
+		return "FiredLimit"
+	case Ignored:
+		return "Ignored"
+	}
+	return "Unknown"
+}
+
+// NewWithOutcome is like New, but the debounced function reports what the
+// call did, e.g. whether it executed the function on the leading edge.
+func NewWithOutcome(after time.Duration, opts ...Option) func(f func()) CallOutcome {
+	d := newDebouncer(after, opts)
+
+	return func(f func()) CallOutcome {
+		d.lock()
+		return d.schedule(f, nil, time.Time{})
+	}
+}
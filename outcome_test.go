@@ -0,0 +1,68 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestOutcomeLeading(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.NewWithOutcome(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithLeading(true),
+	)
+
+	for i := 0; i < 2; i++ {
+		if outcome := debounced(f); outcome != debounce.FiredLeading {
+			t.Fatal("Expected FiredLeading, got", outcome)
+		}
+		if c := int(atomic.LoadUint64(&counter)); c != 2*i+1 {
+			t.Fatalf("Expected count %d, was %d", 2*i+1, c)
+		}
+		for j := 0; j < 3; j++ {
+			clock.Advance(10 * time.Millisecond)
+			if outcome := debounced(f); outcome != debounce.Deferred {
+				t.Fatal("Expected Deferred, got", outcome)
+			}
+		}
+		clock.Advance(time.Second)
+		if c := int(atomic.LoadUint64(&counter)); c != 2*i+2 {
+			t.Fatalf("Expected count %d, was %d", 2*i+2, c)
+		}
+	}
+}
+
+func TestOutcomeLeadingSingleCall(t *testing.T) {
+	var counter uint64
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.NewWithOutcome(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithLeading(true),
+	)
+
+	debounced(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+	clock.Advance(time.Second)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// Panic describes a panic recovered from an executed function,
+// see WithPanicHandler.
+type Panic struct {
+	// The index of the function among the functions executed together,
+	// e.g. by NewCollecting. It's 0 for a single function.
+	Index int
+
+	// The value passed to panic.
+	Value any
+
+	// The name of the Debouncer, see WithName.
+	Name string
+}
+
+// panicHandler returns the handler set with WithPanicHandler, if any,
+// passing the name of d along.
+// d.mu must be held.
+func (d *Debouncer) panicHandler() func(p Panic) {
+	handler, name := d.cfg.panicHandler, d.cfg.name
+	if handler == nil || name == "" {
+		return handler
+	}
+	return func(p Panic) {
+		p.Name = name
+		handler(p)
+	}
+}
+
+// isolate returns a function that executes fns in order, recovering the
+// panics of each so that all of them are executed. The panics are passed to
+// handler or, if handler is nil, the first one is re-raised at the end.
+func isolate(handler func(p Panic), fns ...func()) func() {
+	if handler == nil && len(fns) == 1 {
+		return fns[0]
+	}
+
+	return func() {
+		var first *Panic
+		for i, f := range fns {
+			p := protect(i, f)
+			switch {
+			case p == nil:
+			case handler != nil:
+				handler(*p)
+			case first == nil:
+				first = p
+			}
+		}
+		if first != nil {
+			panic(first.Value)
+		}
+	}
+}
+
+// protect executes f, returning the panic recovered, if any.
+func protect(i int, f func()) (p *Panic) {
+	defer func() {
+		if r := recover(); r != nil {
+			p = &Panic{Index: i, Value: r}
+		}
+	}()
+	f()
+	return nil
+}
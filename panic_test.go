@@ -0,0 +1,163 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestPanicHandlerCollecting(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		attempted []int
+		panics    []debounce.Panic
+	)
+
+	debounced := debounce.NewCollecting(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithPanicHandler(func(p debounce.Panic) {
+			panics = append(panics, p)
+		}),
+	)
+
+	for i := 0; i < 4; i++ {
+		i := i
+		debounced(func() {
+			attempted = append(attempted, i)
+			if i == 1 {
+				panic("failed")
+			}
+		})
+	}
+
+	clock.Advance(time.Second)
+
+	if expected := []int{0, 1, 2, 3}; !reflect.DeepEqual(attempted, expected) {
+		t.Errorf("Expected %v attempted, got %v", expected, attempted)
+	}
+	if expected := []debounce.Panic{{Index: 1, Value: "failed"}}; !reflect.DeepEqual(panics, expected) {
+		t.Errorf("Expected %v, got %v", expected, panics)
+	}
+}
+
+func TestPanicWithoutHandler(t *testing.T) {
+	var attempted []int
+
+	d := debounce.NewDebouncer(time.Hour, debounce.WithOrderedAll(true))
+
+	for i := 0; i < 3; i++ {
+		i := i
+		d.Do(func() {
+			attempted = append(attempted, i)
+			if i == 0 {
+				panic("failed")
+			}
+		})
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "failed" {
+				t.Error("Expected the panic to be re-raised, got", r)
+			}
+		}()
+		d.Flush()
+	}()
+
+	if expected := []int{0, 1, 2}; !reflect.DeepEqual(attempted, expected) {
+		t.Errorf("Expected %v attempted, got %v", expected, attempted)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		recovered []any
+		executed  int
+	)
+
+	debounced := debounce.New(
+		50*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithName("recover"),
+		debounce.WithRecover(func(name string, v any) {
+			if name != "recover" {
+				t.Error("Expected name recover, got", name)
+			}
+			recovered = append(recovered, v)
+		}),
+	)
+
+	debounced(func() {
+		panic("failed")
+	})
+
+	clock.Advance(100 * time.Millisecond)
+
+	if !reflect.DeepEqual(recovered, []any{"failed"}) {
+		t.Fatal("Expected the panic to be recovered, got", recovered)
+	}
+
+	debounced(func() {
+		executed++
+	})
+
+	clock.Advance(100 * time.Millisecond)
+
+	if executed != 1 {
+		t.Error("Expected the next call to execute, got", executed)
+	}
+}
+
+func TestWithRecoverNil(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour, debounce.WithRecover(func(name string, v any) {
+		t.Error("Expected the handler to be replaced")
+	}), debounce.WithRecover(nil))
+
+	d.Do(func() {
+		panic("boom")
+	})
+
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Error("Expected the original panic value, got", v)
+		}
+	}()
+	d.Flush()
+	t.Error("Expected Flush to panic")
+}
+
+func TestPanicRunsWaitingFire(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour, debounce.WithQueueDuringExecution(true), debounce.WithMaxCalls(1))
+
+	executed := make(chan struct{})
+
+	func() {
+		defer func() {
+			if v := recover(); v != "failed" {
+				t.Error("Expected the panic to reach the caller, got", v)
+			}
+		}()
+		d.Do(func() {
+			// Waits for this execution to complete.
+			d.Do(func() {
+				close(executed)
+			})
+			panic("failed")
+		})
+	}()
+
+	select {
+	case <-executed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the waiting function to be executed")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// Pause stops the timer until Resume is called, e.g. to keep the pending
+// function from executing during a bulk import. While paused, calls are
+// still recorded, but nothing is executed, except by Flush and Close.
+// Pausing a paused Debouncer is a no-op.
+func (d *Debouncer) Pause() {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if d.paused {
+		return
+	}
+	d.paused = true
+	d.pausedAt = d.clock.Now()
+	d.pausedUntil = d.deadline
+	if d.pending() {
+		d.stopTimer()
+	}
+}
+
+// Resume resumes the timer stopped by Pause. The quiet window keeps running
+// while paused, so if it has passed, the pending function is executed right
+// away on the calling goroutine. The paused time doesn't count towards
+// WithMaxWait.
+func (d *Debouncer) Resume() {
+	d.lock()
+	if !d.paused {
+		d.mu.Unlock()
+		return
+	}
+	d.paused = false
+	now := d.clock.Now()
+	if !d.waitFrom.IsZero() {
+		d.waitFrom = d.waitFrom.Add(now.Sub(d.pausedAt))
+	}
+
+	if !d.pending() || (d.waiting && d.running > 0) {
+		// A waiting fire is executed when the executions
+		// in flight complete.
+		d.mu.Unlock()
+		return
+	}
+
+	reason := d.reason
+	deadline := d.pausedUntil
+	if d.waiting || deadline.IsZero() || d.lastCall.After(d.pausedAt) {
+		// Called while paused.
+		deadline = d.lastCall.Add(d.interval(now))
+		reason = ReasonQuiet
+	}
+	if d.cfg.maxWait > 0 {
+		if maxWait := d.waitFrom.Add(d.cfg.maxWait); maxWait.Before(deadline) {
+			deadline = maxWait
+			reason = ReasonMaxWait
+		}
+	}
+	d.waiting = false
+
+	if wait := deadline.Sub(now); wait > 0 {
+		d.arm(wait, reason)
+		d.mu.Unlock()
+		return
+	}
+	if d.mustWait() {
+		d.reason = reason
+		d.waiting = true
+		d.mu.Unlock()
+		return
+	}
+
+	f := d.takeForExecution(reason)
+	d.gateLeading(reason, d.interval(now))
+	d.mu.Unlock()
+	d.execute(f)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestPauseResume(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	debounced, controls := debounce.NewWithControls(50*time.Millisecond, debounce.WithClock(clock))
+
+	f := func() {
+		fired = append(fired, clock.Now().Sub(start))
+	}
+
+	debounced(f)
+	controls.Pause()
+	clock.Advance(30 * time.Millisecond)
+	debounced(f)
+	clock.Advance(time.Second)
+
+	if len(fired) != 0 {
+		t.Fatal("Expected nothing executed while paused, got", fired)
+	}
+
+	// The quiet window has passed while paused.
+	controls.Resume()
+
+	// Resumed before the quiet window has passed, which keeps its deadline.
+	debounced(f)
+	controls.Pause()
+	clock.Advance(20 * time.Millisecond)
+	controls.Resume()
+	clock.Advance(time.Second)
+
+	expected := []time.Duration{1030 * time.Millisecond, 1080 * time.Millisecond}
+	if fmt.Sprint(fired) != fmt.Sprint(expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, fired)
+	}
+}
+
+func TestPauseMaxWait(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var fired []time.Duration
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock), debounce.WithMaxWait(100*time.Millisecond))
+
+	f := func() {
+		fired = append(fired, clock.Now().Sub(start))
+	}
+
+	// The calls never stop, but the max wait is reached after 100ms of
+	// unpaused time, at 60ms + 200ms + 40ms.
+	for i := 0; i < 40; i++ {
+		switch i {
+		case 6:
+			d.Pause()
+		case 26:
+			d.Resume()
+		}
+		d.Do(f)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	if len(fired) == 0 || fired[0] != 300*time.Millisecond {
+		t.Errorf("Expected the first execution at 300ms, got %v", fired)
+	}
+}
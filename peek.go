@@ -0,0 +1,53 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// PeekCalls returns the number of calls in the current burst without
+// affecting it.
+//
+// It's safe to call from the executed function itself, where, unless new
+// calls have arrived, it returns the number of calls that triggered the
+// execution.
+func (d *Debouncer) PeekCalls() int {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if d.calls == 0 && d.running > 0 {
+		return d.execCalls
+	}
+	return d.calls
+}
+
+// IsPending reports whether a function is scheduled for execution.
+// It returns false once the pending function has started executing.
+func (d *Debouncer) IsPending() bool {
+	d.lock()
+	defer d.mu.Unlock()
+
+	return d.pending()
+}
+
+// Remaining returns the time left until the pending function is executed,
+// taking WithMaxWait and the other limits into account, or 0 if nothing
+// is pending.
+func (d *Debouncer) Remaining() time.Duration {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if !d.pending() || d.timer == nil {
+		return 0
+	}
+	return max(d.deadline.Sub(d.clock.Now()), 0)
+}
+
+// LastRun returns when the most recent execution started, or the zero time
+// if nothing has been executed yet.
+func (d *Debouncer) LastRun() time.Time {
+	d.lock()
+	defer d.mu.Unlock()
+
+	return d.lastRun
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestPeekCalls(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock))
+
+	if c := d.PeekCalls(); c != 0 {
+		t.Fatal("Expected 0 calls, got", c)
+	}
+
+	inside := -1
+	f := func() {
+		inside = d.PeekCalls()
+	}
+
+	d.Do(f)
+	d.Do(f)
+	if c := d.PeekCalls(); c != 2 {
+		t.Fatal("Expected 2 calls, got", c)
+	}
+	if c := d.PeekCalls(); c != 2 {
+		t.Fatal("Expected PeekCalls to have no side effects, got", c)
+	}
+	d.Do(f)
+
+	clock.Advance(time.Second)
+
+	if inside != 3 {
+		t.Error("Expected 3 calls inside the executed function, got", inside)
+	}
+	if c := d.PeekCalls(); c != 0 {
+		t.Error("Expected 0 calls after execution, got", c)
+	}
+
+	// Also when executed synchronously by Flush.
+	d.Do(f)
+	d.Flush()
+	if inside != 1 {
+		t.Error("Expected 1 call inside the flushed function, got", inside)
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced, controls := debounce.NewWithControls(100*time.Millisecond, debounce.WithClock(clock), debounce.WithMaxWait(250*time.Millisecond))
+
+	if r := controls.Remaining(); r != 0 {
+		t.Fatal("Expected 0 remaining, got", r)
+	}
+
+	for _, expected := range []time.Duration{100, 100, 100, 70} {
+		debounced(func() {})
+		if r := controls.Remaining(); r != expected*time.Millisecond {
+			t.Fatalf("Expected %dms remaining, got %s", expected, r)
+		}
+		clock.Advance(60 * time.Millisecond)
+	}
+
+	// The max wait is reached at 250ms.
+	clock.Advance(10 * time.Millisecond)
+
+	if r := controls.Remaining(); r != 0 {
+		t.Error("Expected 0 remaining after execution, got", r)
+	}
+}
+
+func TestLastRun(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock))
+
+	if !d.LastRun().IsZero() {
+		t.Fatal("Expected zero LastRun before any execution")
+	}
+
+	var (
+		wg      sync.WaitGroup
+		counter uint64
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Do(func() {
+				atomic.AddUint64(&counter, 1)
+			})
+		}()
+	}
+	wg.Wait()
+
+	clock.Advance(50 * time.Millisecond)
+	expected := clock.Now().Add(50 * time.Millisecond)
+	clock.Advance(time.Second)
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+	if lastRun := d.LastRun(); !lastRun.Equal(expected) {
+		t.Errorf("Expected LastRun %s, got %s", expected, lastRun)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// QueuePolicy decides what happens when a queue bounded by WithMaxQueued
+// is full.
+type QueuePolicy int
+
+const (
+	// DropOldest discards the oldest queued function.
+	DropOldest QueuePolicy = iota
+
+	// DropNewest discards the function being added.
+	DropNewest
+
+	// ForceFlush executes all of the queued functions right away, as if
+	// Flush was called, and then queues the function being added.
+	// Nothing is discarded.
+	ForceFlush
+)
+
+// NewCollecting is like New, but every function passed during a burst is
+// executed, in submission order, when the debounced function stops being
+// called for the given duration. See WithMaxQueued.
+func NewCollecting(after time.Duration, opts ...Option) func(f func()) {
+	d := newDebouncer(after, opts)
+	d.cfg.collect = true
+
+	return d.Do
+}
+
+// enqueue adds a function to the queue and starts draining it, if needed.
+// d.mu must be held.
+func (d *Debouncer) enqueue(f func(), fi func(info FireInfo)) {
+	d.push(f, fi)
+	d.call(d.clock.Now())
+
+	if d.timer == nil && !d.paused {
+		d.arm(d.interval(d.clock.Now()), ReasonQuiet)
+	}
+}
+
+// push adds a function to the queue, discarding a function if the queue is
+// full. Full queues with the ForceFlush policy must be flushed before.
+// d.mu must be held.
+func (d *Debouncer) push(f func(), fi func(info FireInfo)) {
+	entry := func(FireInfo) { f() }
+	if fi != nil {
+		entry = func(info FireInfo) {
+			// Every queued function is its own call.
+			info.Calls = 1
+			fi(info)
+		}
+	}
+
+	key := d.callKey
+	if key == 0 {
+		key = pendingKey(f, fi)
+	}
+
+	if d.queueFull() {
+		d.stats.queueDropped++
+		if d.cfg.queuePolicy == DropNewest {
+			return
+		}
+		n := copy(d.queue, d.queue[1:])
+		d.queue = d.queue[:n]
+		copy(d.queueKeys, d.queueKeys[1:])
+		d.queueKeys = d.queueKeys[:n]
+	}
+	d.queue = append(d.queue, entry)
+	d.queueKeys = append(d.queueKeys, key)
+}
+
+func (d *Debouncer) queueFull() bool {
+	return d.cfg.maxQueued > 0 && len(d.queue) >= d.cfg.maxQueued
+}
+
+// dequeue returns the next queued function, and keeps draining the queue if
+// there are more.
+// d.mu must be held.
+func (d *Debouncer) dequeue() func() {
+	if len(d.queue) <= 1 {
+		return d.take()
+	}
+
+	fi, info := d.queue[0], d.fireInfo()
+	d.queue[0] = nil
+	d.queue = d.queue[1:]
+	d.queueKeys = d.queueKeys[1:]
+	d.arm(d.interval(d.clock.Now()), ReasonQuiet)
+
+	return isolate(d.panicHandler(), func() { fi(info) })
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestOrderedAll(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var (
+		executed []int
+		at       []time.Duration
+	)
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithOrderedAll(true),
+	)
+
+	for i := 0; i < 5; i++ {
+		i := i
+		d.Do(func() {
+			executed = append(executed, i)
+			at = append(at, clock.Now().Sub(start))
+		})
+	}
+
+	clock.Advance(time.Second)
+
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(executed, expected) {
+		t.Fatalf("Expected %v, got %v", expected, executed)
+	}
+	for i, a := range at {
+		if expected := time.Duration(i+1) * 100 * time.Millisecond; a != expected {
+			t.Errorf("Expected execution %d at %s, got %s", i, expected, a)
+		}
+	}
+}
+
+func TestOrderedAllMaxQueued(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		policy   debounce.QueuePolicy
+		expected []int
+	}{
+		{"DropOldest", debounce.DropOldest, []int{2, 3, 4}},
+		{"DropNewest", debounce.DropNewest, []int{0, 1, 2}},
+		{"ForceFlush", debounce.ForceFlush, []int{0, 1, 2, 3, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+
+			var executed []int
+
+			d := debounce.NewDebouncer(
+				100*time.Millisecond,
+				debounce.WithClock(clock),
+				debounce.WithOrderedAll(true),
+				debounce.WithMaxQueued(3, test.policy),
+			)
+
+			for i := 0; i < 5; i++ {
+				i := i
+				d.Do(func() {
+					executed = append(executed, i)
+				})
+			}
+
+			clock.Advance(time.Second)
+
+			if !reflect.DeepEqual(executed, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, executed)
+			}
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	var executed []string
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock), debounce.WithQueue(3))
+
+	for i := 0; i < 5; i++ {
+		i := i
+		d.Do(func() {
+			executed = append(executed, fmt.Sprintf("%d at %s", i, clock.Now().Sub(start)))
+		})
+	}
+
+	clock.Advance(time.Second)
+
+	expected := []string{"2 at 100ms", "3 at 200ms", "4 at 300ms"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+	if s := d.Stats(); s.QueueDropped != 2 {
+		t.Error("Expected 2 queued functions dropped, got", s.QueueDropped)
+	}
+}
+
+func TestOrderedAllFlush(t *testing.T) {
+	var executed []int
+
+	d := debounce.NewDebouncer(time.Hour, debounce.WithOrderedAll(true))
+
+	for i := 0; i < 3; i++ {
+		i := i
+		d.Do(func() {
+			executed = append(executed, i)
+		})
+	}
+
+	d.Flush()
+
+	if expected := []int{0, 1, 2}; !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+}
+
+func TestCollecting(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var executed []int
+
+	debounced := debounce.NewCollecting(100*time.Millisecond, debounce.WithClock(clock))
+
+	for i := 0; i < 5; i++ {
+		i := i
+		debounced(func() {
+			executed = append(executed, i)
+		})
+		clock.Advance(50 * time.Millisecond)
+	}
+
+	if len(executed) != 0 {
+		t.Fatal("Expected nothing executed, got", executed)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+
+	if expected := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+}
+
+func TestCollectingMaxQueued(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		policy   debounce.QueuePolicy
+		flushed  []int
+		expected []int
+	}{
+		{"DropOldest", debounce.DropOldest, nil, []int{2, 3, 4}},
+		{"DropNewest", debounce.DropNewest, nil, []int{0, 1, 2}},
+		{"ForceFlush", debounce.ForceFlush, []int{0, 1, 2}, []int{0, 1, 2, 3, 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+
+			var executed []int
+
+			debounced := debounce.NewCollecting(
+				100*time.Millisecond,
+				debounce.WithClock(clock),
+				debounce.WithMaxQueued(3, test.policy),
+			)
+
+			for i := 0; i < 5; i++ {
+				i := i
+				debounced(func() {
+					executed = append(executed, i)
+				})
+			}
+
+			if !reflect.DeepEqual(executed, test.flushed) {
+				t.Fatalf("Expected %v flushed, got %v", test.flushed, executed)
+			}
+
+			clock.Advance(time.Second)
+
+			if !reflect.DeepEqual(executed, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, executed)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "sync"
+
+// registry holds the Debouncers created with WithRegister.
+var registry = struct {
+	mu sync.Mutex
+	m  map[*Debouncer]struct{}
+}{
+	m: make(map[*Debouncer]struct{}),
+}
+
+func register(d *Debouncer) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.m[d] = struct{}{}
+}
+
+func deregister(d *Debouncer) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	delete(registry.m, d)
+}
+
+func registered() []*Debouncer {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	ds := make([]*Debouncer, 0, len(registry.m))
+	for d := range registry.m {
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// FlushAllRegistered flushes every Debouncer created with WithRegister that
+// is not closed, e.g. in integration test teardown.
+func FlushAllRegistered() {
+	for _, d := range registered() {
+		d.Flush()
+	}
+}
+
+// CancelAllRegistered cancels the pending work of every Debouncer created
+// with WithRegister that is not closed.
+func CancelAllRegistered() {
+	for _, d := range registered() {
+		d.Cancel()
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestRegistry(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	var ds []*debounce.Debouncer
+	for i := 0; i < 3; i++ {
+		d := debounce.NewDebouncer(time.Hour, debounce.WithRegister(true))
+		d.Do(f)
+		ds = append(ds, d)
+	}
+
+	unregistered := debounce.NewDebouncer(time.Hour)
+	unregistered.Do(f)
+	defer unregistered.Cancel()
+
+	debounce.FlushAllRegistered()
+
+	if c := atomic.LoadUint64(&counter); c != 3 {
+		t.Fatal("Expected count 3, was", c)
+	}
+
+	for _, d := range ds {
+		d.Do(f)
+	}
+	debounce.CancelAllRegistered()
+	debounce.FlushAllRegistered()
+
+	if c := atomic.LoadUint64(&counter); c != 3 {
+		t.Fatal("Expected count 3, was", c)
+	}
+
+	ds[0].Close()
+
+	if debounce.IsRegistered(ds[0]) {
+		t.Error("Expected closed Debouncer to be deregistered")
+	}
+	if !debounce.IsRegistered(ds[1]) {
+		t.Error("Expected Debouncer to be registered")
+	}
+
+	ds[1].Close()
+	ds[2].Close()
+}
+
+func TestRegistryMaxFires(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour, debounce.WithRegister(true), debounce.WithMaxFires(1))
+
+	d.Do(func() {})
+	d.Flush()
+
+	if debounce.IsRegistered(d) {
+		t.Error("Expected Debouncer closed by WithMaxFires to be deregistered")
+	}
+}
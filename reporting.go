@@ -0,0 +1,17 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewReporting is like New, but the debounced function returns the
+// number of executions so far, as of the call.
+func NewReporting(after time.Duration, opts ...Option) func(f func()) uint64 {
+	d := newDebouncer(after, opts)
+
+	return func(f func()) uint64 {
+		d.add(f)
+		return d.fires.Load()
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestReporting(t *testing.T) {
+	var (
+		counter uint64
+		last    uint64
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	clock := debounce.NewFakeClock(time.Now())
+
+	debounced := debounce.NewReporting(50*time.Millisecond, debounce.WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			fires := debounced(f)
+			if fires < last {
+				t.Fatalf("Expected monotonic fire counts, got %d after %d", fires, last)
+			}
+			if fires != atomic.LoadUint64(&counter) {
+				t.Fatalf("Expected %d fires, got %d", atomic.LoadUint64(&counter), fires)
+			}
+			last = fires
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	if last != 2 {
+		t.Error("Expected last reported count 2, was", last)
+	}
+	if c := atomic.LoadUint64(&counter); c != 3 {
+		t.Error("Expected count 3, was", c)
+	}
+}
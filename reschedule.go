@@ -0,0 +1,31 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// RescheduleNext makes the next execution happen after delay.
+//
+// When called from the executed function, and no new function has been
+// passed in the meantime, it schedules the executed function to run again,
+// which allows it to implement its own adaptive cadence.
+// Otherwise, it re-arms the timer of the pending function.
+func (d *Debouncer) RescheduleNext(delay time.Duration) {
+	d.lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return
+	}
+
+	if !d.pending() {
+		if d.running == 0 || d.current == nil {
+			return
+		}
+		d.f, d.fi = d.current, nil
+		d.call(d.clock.Now())
+	}
+
+	d.arm(delay, ReasonQuiet)
+}
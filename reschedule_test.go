@@ -0,0 +1,45 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestRescheduleNext(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+	start := clock.Now()
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock))
+
+	var (
+		delays = []time.Duration{50 * time.Millisecond, 200 * time.Millisecond, 10 * time.Millisecond}
+		at     []time.Duration
+	)
+
+	f := func() {
+		at = append(at, clock.Now().Sub(start))
+		if len(at) <= len(delays) {
+			d.RescheduleNext(delays[len(at)-1])
+		}
+	}
+
+	d.Do(f)
+
+	clock.Advance(10 * time.Second)
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		150 * time.Millisecond,
+		350 * time.Millisecond,
+		360 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(at, expected) {
+		t.Errorf("Expected executions at %v, got %v", expected, at)
+	}
+}
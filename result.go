@@ -0,0 +1,54 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Result debounces functions returning a value of type T and caches the
+// value returned by the most recent execution.
+type Result[T any] struct {
+	d    *Debouncer
+	last atomic.Pointer[result[T]]
+}
+
+type result[T any] struct {
+	v  T
+	at time.Time
+}
+
+// NewWithResult returns a new Result that executes the last function passed
+// to Do when Do stops being called for the given duration.
+func NewWithResult[T any](after time.Duration, opts ...Option) *Result[T] {
+	return &Result[T]{d: newDebouncer(after, opts)}
+}
+
+// Do schedules f for execution, replacing any pending function.
+func (r *Result[T]) Do(f func() T) {
+	r.d.add(func() {
+		v := f()
+		r.last.Store(&result[T]{v: v, at: r.d.clock.Now()})
+	})
+}
+
+// LastResult returns the value returned by the most recent execution without
+// triggering a new one. The bool is false if nothing has been executed yet.
+func (r *Result[T]) LastResult() (T, bool) {
+	if res := r.last.Load(); res != nil {
+		return res.v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// LastResultTime returns when the value returned by LastResult was computed,
+// or the zero time if nothing has been executed yet.
+func (r *Result[T]) LastResultTime() time.Time {
+	if res := r.last.Load(); res != nil {
+		return res.at
+	}
+	return time.Time{}
+}
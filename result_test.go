@@ -0,0 +1,35 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestLastResult(t *testing.T) {
+	r := debounce.NewWithResult[int](50 * time.Millisecond)
+
+	if v, ok := r.LastResult(); ok || v != 0 {
+		t.Errorf("Expected zero value and false, got %d and %t", v, ok)
+	}
+	if !r.LastResultTime().IsZero() {
+		t.Error("Expected zero time")
+	}
+
+	r.Do(func() int { return 32 })
+	r.Do(func() int { return 42 })
+
+	time.Sleep(100 * time.Millisecond)
+
+	v, ok := r.LastResult()
+	if !ok || v != 42 {
+		t.Errorf("Expected 42 and true, got %d and %t", v, ok)
+	}
+	if r.LastResultTime().IsZero() {
+		t.Error("Expected result time to be set")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// Span is a tracing span, see WithSpanFactory. It's satisfied by a thin
+// adapter around e.g. an OpenTelemetry span, so this package doesn't
+// depend on a tracing library.
+type Span interface {
+	// SetAttribute sets an attribute on the span.
+	SetAttribute(key string, value any)
+
+	// End ends the span.
+	End()
+}
+
+// The attributes set on a Span.
+const (
+	SpanAttributeCalls   = "debounce.calls"
+	SpanAttributeElapsed = "debounce.elapsed"
+	SpanAttributeReason  = "debounce.reason"
+	SpanAttributeSeq     = "debounce.seq"
+	SpanAttributeName    = "debounce.name" // Only set with WithName.
+)
+
+// traced returns a function that executes f within a new span
+// describing the burst.
+func traced(newSpan func() Span, info FireInfo, f func()) func() {
+	return func() {
+		span := newSpan()
+		defer span.End()
+		span.SetAttribute(SpanAttributeCalls, info.Calls)
+		span.SetAttribute(SpanAttributeElapsed, info.Elapsed)
+		span.SetAttribute(SpanAttributeReason, info.Reason.String())
+		span.SetAttribute(SpanAttributeSeq, info.Seq)
+		if info.Name != "" {
+			span.SetAttribute(SpanAttributeName, info.Name)
+		}
+		f()
+	}
+}
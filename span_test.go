@@ -0,0 +1,76 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+type fakeSpan struct {
+	attributes map[string]any
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+func (s *fakeSpan) End() {
+	s.ended = true
+}
+
+func TestSpanFactory(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var spans []*fakeSpan
+
+	d := debounce.NewDebouncer(
+		50*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithMaxCalls(3),
+		debounce.WithSpanFactory(func() debounce.Span {
+			s := &fakeSpan{attributes: make(map[string]any)}
+			spans = append(spans, s)
+			return s
+		}),
+	)
+
+	for i := 0; i < 4; i++ {
+		d.Do(func() {})
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	clock.Advance(time.Second)
+
+	expected := []map[string]any{
+		{
+			debounce.SpanAttributeCalls:   3,
+			debounce.SpanAttributeElapsed: 20 * time.Millisecond,
+			debounce.SpanAttributeReason:  "MaxCalls",
+			debounce.SpanAttributeSeq:     uint64(1),
+		},
+		{
+			debounce.SpanAttributeCalls:   1,
+			debounce.SpanAttributeElapsed: 50 * time.Millisecond,
+			debounce.SpanAttributeReason:  "Quiet",
+			debounce.SpanAttributeSeq:     uint64(2),
+		},
+	}
+
+	if len(spans) != len(expected) {
+		t.Fatalf("Expected %d spans, got %d", len(expected), len(spans))
+	}
+	for i, s := range spans {
+		if !s.ended {
+			t.Errorf("Expected span %d to be ended", i)
+		}
+		if !reflect.DeepEqual(s.attributes, expected[i]) {
+			t.Errorf("Expected span %d attributes %v, got %v", i, expected[i], s.attributes)
+		}
+	}
+}
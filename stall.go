@@ -0,0 +1,57 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// watchStall arms the stall detector for the timer armed with the given
+// duration and generation.
+// d.mu must be held.
+func (d *Debouncer) watchStall(after time.Duration, gen uint64) {
+	if d.cfg.onStall == nil {
+		return
+	}
+
+	if d.stallTimer != nil {
+		d.stallTimer.Stop()
+	}
+
+	deadline := d.deadline
+	d.stallTimer = d.clock.AfterFunc(after+d.cfg.stallThreshold, func() {
+		d.lock()
+		onStall := d.cfg.onStall
+		stalled := gen == d.gen && d.pending() && onStall != nil
+		d.mu.Unlock()
+
+		if stalled {
+			onStall(deadline)
+		}
+	})
+}
+
+// watchHandoff keeps the stall detector armed for f, due at deadline, until
+// it starts executing, as it may be held up by the executor set with
+// WithExecutor, and returns f wrapped to stop it.
+// d.mu must be held.
+func (d *Debouncer) watchHandoff(f func(), deadline time.Time) func() {
+	onStall := d.cfg.onStall
+	if onStall == nil {
+		return f
+	}
+
+	var started atomic.Bool
+	t := d.clock.AfterFunc(deadline.Add(d.cfg.stallThreshold).Sub(d.clock.Now()), func() {
+		if !started.Load() {
+			onStall(deadline)
+		}
+	})
+	return func() {
+		started.Store(true)
+		t.Stop()
+		f()
+	}
+}
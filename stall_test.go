@@ -0,0 +1,105 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestStallDetector(t *testing.T) {
+	stalls := make(chan time.Time, 10)
+	block := make(chan struct{})
+	started := make(chan struct{})
+
+	d := debounce.NewDebouncer(
+		20*time.Millisecond,
+		// Executions never overlap, so a stuck function blocks the next one.
+		debounce.WithQueueDuringExecution(true),
+		debounce.WithStallDetector(50*time.Millisecond, func(deadline time.Time) {
+			stalls <- deadline
+		}),
+	)
+
+	d.Do(func() {
+		close(started)
+		<-block
+	})
+	<-started
+
+	before := time.Now()
+	d.Do(func() {})
+
+	select {
+	case deadline := <-stalls:
+		if deadline.Before(before) {
+			t.Error("Expected the deadline of the stalled burst, got", deadline)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a stall to be detected")
+	}
+
+	close(block)
+	time.Sleep(100 * time.Millisecond)
+
+	// A burst that executes on time is not reported.
+	d.Do(func() {})
+	time.Sleep(150 * time.Millisecond)
+
+	select {
+	case <-stalls:
+		t.Error("Expected no stall")
+	default:
+	}
+}
+
+func TestStallDetectorExecutor(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		stalls    []time.Time
+		handedOff []func()
+	)
+
+	d := debounce.NewDebouncer(
+		20*time.Millisecond,
+		debounce.WithClock(clock),
+		// A stuck executor that never runs the functions handed off to it.
+		debounce.WithExecutor(func(f func()) {
+			handedOff = append(handedOff, f)
+		}),
+		debounce.WithStallDetector(50*time.Millisecond, func(deadline time.Time) {
+			stalls = append(stalls, deadline)
+		}),
+	)
+
+	start := clock.Now()
+	d.Do(func() {})
+	clock.Advance(30 * time.Millisecond)
+
+	if len(handedOff) != 1 {
+		t.Fatal("Expected 1 function handed off, got", len(handedOff))
+	}
+	if len(stalls) != 0 {
+		t.Fatal("Expected no stall yet, got", stalls)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if len(stalls) != 1 || !stalls[0].Equal(start.Add(20*time.Millisecond)) {
+		t.Fatal("Expected the stuck executor to be reported, got", stalls)
+	}
+
+	// A function the executor runs in time is not reported.
+	d.Do(func() {})
+	clock.Advance(30 * time.Millisecond)
+	handedOff[1]()
+	clock.Advance(100 * time.Millisecond)
+
+	if len(stalls) != 1 {
+		t.Error("Expected no new stall, got", stalls)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sort"
+	"time"
+)
+
+// Stats holds statistics about a Debouncer.
+type Stats struct {
+	// The name of the Debouncer, see WithName.
+	Name string
+
+	// The number of calls scheduled and the number of executions.
+	// Dropped is the difference: the calls that were coalesced, discarded
+	// or are still pending.
+	Scheduled uint64
+	Executed  uint64
+	Dropped   uint64
+
+	// The largest number of calls coalesced into one execution, and the
+	// number coalesced into the most recent one.
+	MaxBurst  int
+	LastBurst int
+
+	// The number of queued functions discarded because the queue was full,
+	// see WithMaxQueued.
+	QueueDropped uint64
+
+	// The number of executions per trigger.
+	QuietFires   uint64 // The calls stopped for the given duration.
+	LeadingFires uint64 // On the leading edge, see WithLeading.
+	MaxCallFires uint64 // See WithMaxCalls.
+	MaxWaitFires uint64 // See WithMaxWait.
+	FlushFires   uint64 // See Flush and Close.
+
+	// Latency describes the time from the first call in a burst to its
+	// execution. It's only populated when WithLatencyTracking is enabled.
+	Latency LatencyStats
+}
+
+// LatencyStats describes the distribution of burst latencies.
+type LatencyStats struct {
+	// The number of bursts the percentiles are computed from.
+	// This is capped to the most recent bursts.
+	Samples int
+
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// Stats returns a snapshot of the statistics of d.
+func (d *Debouncer) Stats() Stats {
+	d.lock()
+	defer d.mu.Unlock()
+
+	s := Stats{
+		Name:         d.cfg.name,
+		Scheduled:    d.stats.scheduled,
+		Executed:     d.fires.Load(),
+		QuietFires:   d.stats.quiet,
+		LeadingFires: d.stats.leading,
+		MaxCallFires: d.stats.maxCalls,
+		MaxWaitFires: d.stats.maxWait,
+		FlushFires:   d.stats.flush,
+		QueueDropped: d.stats.queueDropped,
+		MaxBurst:     d.stats.maxBurst,
+		LastBurst:    d.stats.lastBurst,
+	}
+	if s.Scheduled > s.Executed {
+		s.Dropped = s.Scheduled - s.Executed
+	}
+	if d.latencies != nil {
+		s.Latency = d.latencies.stats()
+	}
+	return s
+}
+
+// FireReason describes why a function was executed.
+type FireReason int
+
+const (
+	// ReasonQuiet means that the calls stopped for the given duration.
+	ReasonQuiet FireReason = iota
+
+	// ReasonLeading means that the function was executed on the leading
+	// edge, see WithLeading.
+	ReasonLeading
+
+	// ReasonMaxCalls means that the limit set by WithMaxCalls was reached.
+	ReasonMaxCalls
+
+	// ReasonMaxWait means that the limit set by WithMaxWait was reached.
+	ReasonMaxWait
+
+	// ReasonFlush means that the function was executed by Flush or Close.
+	ReasonFlush
+)
+
+func (r FireReason) String() string {
+	switch r {
+	case ReasonQuiet:
+		return "Quiet"
+	case ReasonLeading:
+		return "Leading"
+	case ReasonMaxCalls:
+		return "MaxCalls"
+	case ReasonMaxWait:
+		return "MaxWait"
+	case ReasonFlush:
+		return "Flush"
+	}
+	return "Unknown"
+}
+
+type fireCounts struct {
+	scheduled    uint64
+	queueDropped uint64
+
+	maxBurst  int
+	lastBurst int
+
+	quiet    uint64
+	leading  uint64
+	maxCalls uint64
+	maxWait  uint64
+	flush    uint64
+}
+
+func (c *fireCounts) countBurst(calls int) {
+	c.lastBurst = calls
+	c.maxBurst = max(c.maxBurst, calls)
+}
+
+func (c *fireCounts) countFire(reason FireReason) {
+	switch reason {
+	case ReasonQuiet:
+		c.quiet++
+	case ReasonLeading:
+		c.leading++
+	case ReasonMaxCalls:
+		c.maxCalls++
+	case ReasonMaxWait:
+		c.maxWait++
+	case ReasonFlush:
+		c.flush++
+	}
+}
+
+// maxLatencySamples is the number of most recent burst latencies kept.
+const maxLatencySamples = 1024
+
+// latencies is a ring buffer of burst latencies.
+type latencies struct {
+	samples []time.Duration
+	next    int
+}
+
+func (l *latencies) add(v time.Duration) {
+	if len(l.samples) < maxLatencySamples {
+		l.samples = append(l.samples, v)
+		return
+	}
+	l.samples[l.next] = v
+	l.next = (l.next + 1) % maxLatencySamples
+}
+
+func (l *latencies) stats() LatencyStats {
+	if len(l.samples) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := make([]time.Duration, len(l.samples))
+	copy(sorted, l.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Nearest-rank percentile.
+	percentile := func(p int) time.Duration {
+		i := (len(sorted)*p+99)/100 - 1
+		return sorted[i]
+	}
+
+	return LatencyStats{
+		Samples: len(sorted),
+		P50:     percentile(50),
+		P90:     percentile(90),
+		P99:     percentile(99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestStatsLatency(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithLatencyTracking(true),
+	)
+
+	f := func() {}
+
+	// A burst of 3 calls spaced 50ms apart fires 200ms after the first call.
+	for i := 0; i < 3; i++ {
+		d.Do(f)
+		clock.Advance(50 * time.Millisecond)
+	}
+	clock.Advance(time.Second)
+
+	// A single call fires after 100ms.
+	for i := 0; i < 3; i++ {
+		d.Do(f)
+		clock.Advance(time.Second)
+	}
+
+	latency := d.Stats().Latency
+
+	if latency.Samples != 4 {
+		t.Fatal("Expected 4 samples, got", latency.Samples)
+	}
+	if latency.P50 != 100*time.Millisecond {
+		t.Error("Expected P50 100ms, got", latency.P50)
+	}
+	if latency.P99 != 200*time.Millisecond {
+		t.Error("Expected P99 200ms, got", latency.P99)
+	}
+	if latency.Max != 200*time.Millisecond {
+		t.Error("Expected Max 200ms, got", latency.Max)
+	}
+}
+
+func TestStatsLatencyDisabled(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(100*time.Millisecond, debounce.WithClock(clock))
+
+	d.Do(func() {})
+	clock.Advance(time.Second)
+
+	if latency := d.Stats().Latency; latency.Samples != 0 {
+		t.Error("Expected no samples, got", latency.Samples)
+	}
+}
+
+func TestStatsFireReasons(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(
+		100*time.Millisecond,
+		debounce.WithClock(clock),
+		debounce.WithMaxCalls(5),
+		debounce.WithMaxWait(200*time.Millisecond),
+	)
+
+	f := func() {}
+
+	for i := 0; i < 2; i++ {
+		d.Do(f)
+		clock.Advance(time.Second)
+	}
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			d.Do(f)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		d.Do(f)
+		clock.Advance(60 * time.Millisecond)
+	}
+	clock.Advance(time.Second)
+
+	for i := 0; i < 2; i++ {
+		d.Do(f)
+		d.Flush()
+	}
+	d.Flush()
+
+	s := d.Stats()
+
+	if s.QuietFires != 2 {
+		t.Error("Expected 2 quiet fires, got", s.QuietFires)
+	}
+	if s.MaxCallFires != 3 {
+		t.Error("Expected 3 max call fires, got", s.MaxCallFires)
+	}
+	if s.MaxWaitFires != 1 {
+		t.Error("Expected 1 max wait fire, got", s.MaxWaitFires)
+	}
+	if s.FlushFires != 2 {
+		t.Error("Expected 2 flush fires, got", s.FlushFires)
+	}
+	if s.LeadingFires != 0 {
+		t.Error("Expected 0 leading fires, got", s.LeadingFires)
+	}
+}
+
+func TestStatsCounts(t *testing.T) {
+	var wg sync.WaitGroup
+
+	debounced, controls := debounce.NewWithControls(50 * time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				debounced(func() {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s := controls.Stats(); s.Scheduled != 100 || s.Executed != 0 || s.Dropped != 100 {
+		t.Fatalf("Expected 100 scheduled, 0 executed and 100 dropped, got %+v", s)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if s := controls.Stats(); s.Scheduled != 100 || s.Executed != 1 || s.Dropped != 99 {
+		t.Errorf("Expected 100 scheduled, 1 executed and 99 dropped, got %+v", s)
+	}
+}
+
+func TestStatsBurstSize(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	d := debounce.NewDebouncer(50*time.Millisecond, debounce.WithClock(clock))
+
+	for _, size := range []int{3, 7, 2} {
+		for i := 0; i < size; i++ {
+			d.Do(func() {})
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	if s := d.Stats(); s.MaxBurst != 7 || s.LastBurst != 2 {
+		t.Errorf("Expected MaxBurst 7 and LastBurst 2, got %d and %d", s.MaxBurst, s.LastBurst)
+	}
+}
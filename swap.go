@@ -0,0 +1,41 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// SwapOptions applies opts on top of the current options of d and returns
+// the previous options, which can be passed to SwapOptions to restore them.
+// The new options take effect for the next call; a function already
+// scheduled keeps its deadline.
+//
+// The clock, WithRegister, WithLeakyBucket and WithExecutionTracker are
+// fixed when d is created and are not changed by SwapOptions.
+func (d *Debouncer) SwapOptions(opts ...Option) []Option {
+	d.lock()
+	defer d.mu.Unlock()
+
+	prev := d.cfg
+	for _, opt := range opts {
+		opt.apply(&d.cfg)
+	}
+	d.cfg.clock = prev.clock
+	d.cfg.register = prev.register
+	d.cfg.executionTracker = prev.executionTracker
+	d.cfg.leakCapacity, d.cfg.leakRate = prev.leakCapacity, prev.leakRate
+
+	switch {
+	case d.cfg.latencyTracking && d.latencies == nil:
+		d.latencies = &latencies{}
+	case !d.cfg.latencyTracking:
+		d.latencies = nil
+	}
+
+	return []Option{
+		{
+			kind: "snapshot",
+			apply: func(c *config) {
+				*c = prev
+			},
+		},
+	}
+}
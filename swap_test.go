@@ -0,0 +1,66 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestSwapOptions(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	d := debounce.NewDebouncer(time.Hour, debounce.WithMaxCalls(10))
+
+	prev := d.SwapOptions(debounce.WithMaxCalls(2))
+
+	for i := 0; i < 4; i++ {
+		d.Do(f)
+	}
+
+	if c := int(atomic.LoadUint64(&counter)); c != 2 {
+		t.Fatal("Expected count 2, was", c)
+	}
+
+	d.SwapOptions(prev...)
+
+	for i := 0; i < 9; i++ {
+		d.Do(f)
+	}
+
+	if c := int(atomic.LoadUint64(&counter)); c != 2 {
+		t.Fatal("Expected count 2, was", c)
+	}
+
+	d.Do(f)
+
+	if c := int(atomic.LoadUint64(&counter)); c != 3 {
+		t.Error("Expected count 3, was", c)
+	}
+}
+
+func TestSwapOptionsConcurrent(t *testing.T) {
+	d := debounce.NewDebouncer(time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			d.SwapOptions(debounce.WithOnAbandon(func() {}), debounce.WithCascadeCancel(true))
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		d.Do(func() {})
+		d.Cancel()
+	}
+	<-done
+}
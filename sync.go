@@ -0,0 +1,69 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// syncBurst is the result shared by the callers in a burst, see NewSync.
+type syncBurst[T any] struct {
+	done chan struct{}
+	once sync.Once
+	v    T
+}
+
+// complete records v as the result of the burst, for the first function
+// of the burst to complete.
+func (b *syncBurst[T]) complete(v T) {
+	b.once.Do(func() {
+		b.v = v
+		close(b.done)
+	})
+}
+
+// NewSync returns a debounced function that blocks until the function that
+// won the burst, the last one passed, has been executed, and returns its
+// result. Every caller in the burst receives the winner's result. If the
+// winning function panics, they receive the zero value.
+//
+// With the options executing every function passed, e.g. WithQueue, every
+// caller receives the result of its own function. With WithAccumulateFuncs,
+// where the functions passed are all created by the same function literal,
+// the first one wins.
+//
+// Options that discard the pending function, e.g. WithTrailing(false), must
+// not be used, as the callers would block forever. A call that is ignored,
+// e.g. by WithPreCheck, returns the zero value right away.
+func NewSync[T any](after time.Duration, opts ...Option) func(f func() T) T {
+	d := newDebouncer(after, opts)
+
+	var current *syncBurst[T] // Guarded by d.mu.
+
+	return func(f func() T) T {
+		d.lock()
+		if current == nil || !d.pending() || d.cfg.orderedAll || d.cfg.collect {
+			// The previous burst, if any, has been taken for execution,
+			// or every function is executed.
+			current = &syncBurst[T]{done: make(chan struct{})}
+		}
+		b := current
+		outcome := d.schedule(func() {
+			var v T
+			defer func() {
+				b.complete(v)
+			}()
+			v = f()
+		}, nil, time.Time{})
+
+		if outcome == Ignored {
+			var zero T
+			return zero
+		}
+
+		<-b.done
+		return b.v
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestNewSync(t *testing.T) {
+	var (
+		wg       sync.WaitGroup
+		executed uint64
+		results  [3]int
+	)
+
+	debounced := debounce.NewSync[int](50 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = debounced(func() int {
+				atomic.AddUint64(&executed, 1)
+				return i
+			})
+		}()
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+
+	if c := atomic.LoadUint64(&executed); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+	if results != [3]int{2, 2, 2} {
+		t.Error("Expected every caller to receive the winner's result, got", results)
+	}
+
+	// A new burst.
+	if v := debounced(func() int { return 42 }); v != 42 {
+		t.Error("Expected 42, got", v)
+	}
+}
+
+func TestNewSyncEveryFunction(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opt      debounce.Option
+		expected [3]int
+	}{
+		{"OrderedAll", debounce.WithOrderedAll(true), [3]int{0, 1, 2}},
+		{"Queue", debounce.WithQueue(10), [3]int{0, 1, 2}},
+		{"AccumulateFuncs", debounce.WithAccumulateFuncs(true), [3]int{0, 0, 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				wg      sync.WaitGroup
+				results [3]int
+			)
+
+			debounced := debounce.NewSync[int](10*time.Millisecond, test.opt)
+
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i] = debounced(func() int {
+						return i
+					})
+				}()
+				// Keeps the submission order.
+				time.Sleep(2 * time.Millisecond)
+			}
+			wg.Wait()
+
+			if results != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewTagged is like New, but each call also contributes a tag. The last
+// function is executed with the tags of all the calls in the burst, in call
+// order. See WithDedupTags.
+func NewTagged(after time.Duration, opts ...Option) func(f func(tags []string), tag string) {
+	t := &tagged{d: newDebouncer(after, opts)}
+	t.d.bind = t.bind
+	t.d.bindDropped = t.bindDropped
+
+	return t.add
+}
+
+type tagged struct {
+	d *Debouncer
+
+	// The latest function and the tags of the burst. Guarded by d.mu.
+	f    func(tags []string)
+	tags []string
+	seen map[string]bool
+
+	// The function superseded by the call being scheduled, see WithOnDrop.
+	dropped func(tags []string)
+}
+
+func (t *tagged) add(f func(tags []string), tag string) {
+	if f == nil {
+		return
+	}
+
+	d := t.d
+	d.lock()
+	if d.cfg.orderedAll || d.cfg.collect || d.cfg.accumulate {
+		// Every function is executed, each with its own tag.
+		d.schedule(func() { f([]string{tag}) }, nil, time.Time{})
+		return
+	}
+	if !d.pending() {
+		// Drop the tags of ignored calls, e.g. vetoed by WithPreCheck.
+		t.tags, t.seen = nil, nil
+	}
+	if d.replacesPending() {
+		t.dropped, t.f = t.f, f
+	} else {
+		t.dropped = f
+	}
+	if !d.cfg.dedupTags {
+		t.tags = append(t.tags, tag)
+	} else if !t.seen[tag] {
+		if t.seen == nil {
+			t.seen = make(map[string]bool)
+		}
+		t.seen[tag] = true
+		t.tags = append(t.tags, tag)
+	}
+	d.schedule(noop, nil, time.Time{})
+}
+
+// bind returns a function calling the latest function with the tags of the
+// burst.
+// d.mu must be held.
+func (t *tagged) bind() func() {
+	f, tags := t.f, t.tags
+	t.f, t.tags, t.seen = nil, nil, nil
+	return func() { f(tags) }
+}
+
+// bindDropped returns a function calling the superseded function with the
+// tags of the burst so far.
+// d.mu must be held.
+func (t *tagged) bindDropped() func() {
+	f, tags := t.dropped, t.tags[:len(t.tags):len(t.tags)]
+	t.dropped = nil
+	return func() { f(tags) }
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestTagged(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     []debounce.Option
+		expected []string
+	}{
+		{"Default", nil, []string{"a", "b", "a", "c", "b"}},
+		{"Dedup", []debounce.Option{debounce.WithDedupTags(true)}, []string{"a", "b", "c"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				calls int
+				got   []string
+			)
+
+			f := func(tags []string) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				got = tags
+			}
+
+			debounced := debounce.NewTagged(50*time.Millisecond, test.opts...)
+
+			for _, tag := range []string{"a", "b", "a", "c", "b"} {
+				debounced(f, tag)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if calls != 1 {
+				t.Error("Expected 1 call, got", calls)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Expected tags %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestTaggedExecutor(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var (
+		got       []string
+		handedOff []func()
+	)
+
+	debounced := debounce.NewTagged(50*time.Millisecond, debounce.WithClock(clock), debounce.WithExecutor(func(f func()) {
+		handedOff = append(handedOff, f)
+	}))
+
+	debounced(func(tags []string) {
+		got = append(got, "A"+fmt.Sprint(tags))
+	}, "a")
+	clock.Advance(100 * time.Millisecond)
+
+	// Called before the first burst has been run by the executor.
+	debounced(func(tags []string) {
+		got = append(got, "B"+fmt.Sprint(tags))
+	}, "b")
+	clock.Advance(100 * time.Millisecond)
+
+	for _, f := range handedOff {
+		f()
+	}
+
+	if expected := []string{"A[a]", "B[b]"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestTaggedOnDrop(t *testing.T) {
+	var got []string
+
+	debounced := debounce.NewTagged(time.Hour, debounce.WithOnDrop(func(f func()) {
+		f()
+	}))
+
+	debounced(func(tags []string) {
+		got = append(got, "A"+fmt.Sprint(tags))
+	}, "a")
+	debounced(func(tags []string) {
+		got = append(got, "B"+fmt.Sprint(tags))
+	}, "b")
+
+	if expected := []string{"A[a b]"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
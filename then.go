@@ -0,0 +1,54 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+type stage struct {
+	next func(func())
+	f    func()
+
+	// The downstream Debouncer, if known.
+	d *Debouncer
+}
+
+// Then wires the executions of d to a downstream debounced function:
+// every time a function executed by d returns, f is passed to next.
+// The downstream stage is never triggered before the upstream function has
+// fully returned.
+func (d *Debouncer) Then(next func(func()), f func()) {
+	d.addStage(stage{next: next, f: f})
+}
+
+// Chain is like Then, but with a downstream Debouncer, which allows
+// WithCascadeCancel to propagate cancellation to it.
+func (d *Debouncer) Chain(next *Debouncer, f func()) {
+	d.addStage(stage{next: next.Do, f: f, d: next})
+}
+
+func (d *Debouncer) addStage(s stage) {
+	d.lock()
+	defer d.mu.Unlock()
+
+	// Copy on write, so execute can iterate without holding the lock.
+	thens := make([]stage, len(d.thens), len(d.thens)+1)
+	copy(thens, d.thens)
+	d.thens = append(thens, s)
+}
+
+// cascadeCancel cancels the pending work of the downstream Debouncers
+// if WithCascadeCancel is enabled.
+func (d *Debouncer) cascadeCancel() {
+	d.lock()
+	if !d.cfg.cascadeCancel {
+		d.mu.Unlock()
+		return
+	}
+	thens := d.thens
+	d.mu.Unlock()
+
+	for _, s := range thens {
+		if s.d != nil {
+			s.d.Cancel()
+		}
+	}
+}
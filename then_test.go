@@ -0,0 +1,84 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestThen(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+
+	record := func(s string) {
+		mu.Lock()
+		events = append(events, s)
+		mu.Unlock()
+	}
+
+	upstream := debounce.NewDebouncer(20 * time.Millisecond)
+	downstream := debounce.New(10 * time.Millisecond)
+
+	upstream.Then(downstream, func() {
+		record("downstream")
+	})
+
+	upstream.Do(func() {
+		record("upstream start")
+		time.Sleep(50 * time.Millisecond)
+		record("upstream done")
+	})
+
+	time.Sleep(150 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []string{"upstream start", "upstream done", "downstream"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, events)
+	}
+	for i, e := range expected {
+		if events[i] != e {
+			t.Fatalf("Expected %v, got %v", expected, events)
+		}
+	}
+}
+
+func TestChainCascadeCancel(t *testing.T) {
+	for _, cascade := range []bool{false, true} {
+		var counter uint64
+
+		upstream := debounce.NewDebouncer(10*time.Millisecond, debounce.WithCascadeCancel(cascade))
+		downstream := debounce.NewDebouncer(100 * time.Millisecond)
+
+		upstream.Chain(downstream, func() {
+			atomic.AddUint64(&counter, 1)
+		})
+
+		upstream.Do(func() {})
+
+		// The upstream has executed, the downstream is pending.
+		time.Sleep(50 * time.Millisecond)
+
+		upstream.Cancel()
+
+		time.Sleep(150 * time.Millisecond)
+
+		expected := 1
+		if cascade {
+			expected = 0
+		}
+		if c := int(atomic.LoadUint64(&counter)); c != expected {
+			t.Errorf("[cascade=%t] Expected count %d, was %d", cascade, expected, c)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewThrottle returns a throttled function that takes another function as
+// its argument. Unlike a debounced function, it executes at most one
+// function per interval while the calls are ongoing: the first call is
+// executed right away, and the last call made during an interval is
+// executed when the interval has passed, starting a new one.
+func NewThrottle(interval time.Duration, opts ...Option) func(f func()) {
+	d := newDebouncer(interval, opts)
+	d.cfg.throttle = true
+
+	return d.Do
+}
+
+// throttle registers a call made during an interval, see NewThrottle.
+// Unlike a debounced call, it doesn't move the deadline.
+// d.mu must be held.
+func (d *Debouncer) throttle() {
+	now := d.clock.Now()
+	d.call(now)
+	if d.timer == nil {
+		d.arm(d.interval(now), ReasonQuiet)
+	}
+}
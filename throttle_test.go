@@ -0,0 +1,54 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestThrottle(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		new      func(time.Duration, ...debounce.Option) func(func())
+		expected []string
+	}{
+		{
+			name:     "throttle",
+			new:      debounce.NewThrottle,
+			expected: []string{"0 at 0s", "2 at 30ms", "5 at 60ms", "8 at 90ms", "9 at 120ms"},
+		},
+		{
+			name:     "debounce",
+			new:      debounce.New,
+			expected: []string{"9 at 120ms"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clock := debounce.NewFakeClock(time.Now())
+			start := clock.Now()
+
+			var got []string
+
+			f := test.new(30*time.Millisecond, debounce.WithClock(clock))
+
+			for i := 0; i < 10; i++ {
+				i := i
+				f(func() {
+					got = append(got, fmt.Sprintf("%d at %s", i, clock.Now().Sub(start)))
+				})
+				clock.Advance(10 * time.Millisecond)
+			}
+
+			clock.Advance(time.Second)
+
+			if fmt.Sprint(got) != fmt.Sprint(test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "sync/atomic"
+
+// ConcurrentExecutionsSeen reports whether two executions of d have ever
+// overlapped. It always returns false unless WithExecutionTracker is enabled.
+func (d *Debouncer) ConcurrentExecutionsSeen() bool {
+	d.lock()
+	t := d.tracker
+	d.mu.Unlock()
+
+	return t != nil && t.overlapped.Load()
+}
+
+type executionTracker struct {
+	active     atomic.Int32
+	overlapped atomic.Bool
+}
+
+func (t *executionTracker) enter() {
+	if t.active.Add(1) > 1 {
+		t.overlapped.Store(true)
+	}
+}
+
+func (t *executionTracker) exit() {
+	t.active.Add(-1)
+}
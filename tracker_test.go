@@ -0,0 +1,43 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestExecutionTracker(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		serialize  bool
+		overlapped bool
+	}{
+		{"concurrent", false, true},
+		{"serialized", true, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d := debounce.NewDebouncer(
+				10*time.Millisecond,
+				debounce.WithExecutionTracker(true),
+				debounce.WithQueueDuringExecution(test.serialize),
+			)
+
+			for i := 0; i < 5; i++ {
+				d.Do(func() {
+					time.Sleep(50 * time.Millisecond)
+				})
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			d.Close()
+
+			if seen := d.ConcurrentExecutionsSeen(); seen != test.overlapped {
+				t.Errorf("Expected overlap %t, got %t", test.overlapped, seen)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "time"
+
+// NewVersioned is like New, but each call also takes a version. A call is
+// ignored unless its version is greater than the highest version seen so
+// far, which makes sure that a late, out-of-order call never replaces
+// newer state. Calls ignored for other reasons, e.g. a nil f or a
+// WithPreCheck veto, don't count as seen.
+func NewVersioned(after time.Duration, opts ...Option) func(f func(), version uint64) {
+	d := newDebouncer(after, opts)
+
+	var (
+		seen   bool
+		latest uint64 // Guarded by d.mu.
+	)
+
+	return func(f func(), version uint64) {
+		d.lock()
+		if (seen && version <= latest) || f == nil || d.closed || d.disabled() {
+			d.mu.Unlock()
+			return
+		}
+		prevSeen, prev := seen, latest
+		seen, latest = true, version
+		if d.schedule(f, nil, time.Time{}) != Ignored {
+			return
+		}
+
+		// Vetoed, e.g. by WithPreCheck, so the version isn't seen.
+		d.lock()
+		if seen && latest == version {
+			seen, latest = prevSeen, prev
+		}
+		d.mu.Unlock()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestVersioned(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var fired []uint64
+
+	debounced := debounce.NewVersioned(50*time.Millisecond, debounce.WithClock(clock))
+
+	call := func(version uint64) {
+		debounced(func() { fired = append(fired, version) }, version)
+	}
+
+	for _, version := range []uint64{3, 1, 2, 5} {
+		call(version)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+
+	if len(fired) != 1 || fired[0] != 5 {
+		t.Fatal("Expected version 5 to fire, got", fired)
+	}
+
+	// Stale calls are ignored in later bursts, too.
+	call(4)
+	clock.Advance(100 * time.Millisecond)
+
+	if len(fired) != 1 {
+		t.Fatal("Expected stale version to be ignored, got", fired)
+	}
+
+	call(6)
+	clock.Advance(100 * time.Millisecond)
+
+	if len(fired) != 2 || fired[1] != 6 {
+		t.Error("Expected version 6 to fire, got", fired)
+	}
+}
+
+func TestVersionedIgnoredCallKeepsVersion(t *testing.T) {
+	clock := debounce.NewFakeClock(time.Now())
+
+	var fired []uint64
+	veto := true
+
+	debounced := debounce.NewVersioned(50*time.Millisecond, debounce.WithClock(clock),
+		debounce.WithPreCheck(func() bool { return !veto }))
+
+	call := func(version uint64) {
+		debounced(func() { fired = append(fired, version) }, version)
+	}
+
+	debounced(nil, 7)
+	call(5)
+	clock.Advance(100 * time.Millisecond)
+
+	if len(fired) != 0 {
+		t.Fatal("Expected vetoed call to be ignored, got", fired)
+	}
+
+	veto = false
+	call(3)
+	clock.Advance(100 * time.Millisecond)
+
+	if len(fired) != 1 || fired[0] != 3 {
+		t.Error("Expected version 3 to fire, got", fired)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+import "context"
+
+// WaitForIdle blocks until no function is pending, no timer is armed and no
+// execution is in flight, e.g. to wait for the debounced work to complete in
+// a test without sleeping. It returns ctx.Err() if ctx is done before that.
+// The quiet window after a leading execution, see WithLeading, counts as
+// armed.
+func (d *Debouncer) WaitForIdle(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		d.lock()
+		d.idle.Broadcast()
+		d.mu.Unlock()
+	})
+	defer stop()
+
+	d.lock()
+	defer d.mu.Unlock()
+
+	for !d.isIdle() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d.wait()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestWaitForIdle(t *testing.T) {
+	var counter uint64
+
+	f := func() {
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddUint64(&counter, 1)
+	}
+
+	debounced, controls := debounce.NewWithControls(20 * time.Millisecond)
+
+	if err := controls.WaitForIdle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		debounced(f)
+	}
+
+	if err := controls.WaitForIdle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestWaitForIdleContext(t *testing.T) {
+	d := debounce.NewDebouncer(time.Hour)
+	d.Do(func() {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := d.WaitForIdle(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected context.DeadlineExceeded, got", err)
+	}
+
+	d.Cancel()
+
+	if err := d.WaitForIdle(context.Background()); err != nil {
+		t.Error("Expected idle after Cancel, got", err)
+	}
+}
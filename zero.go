@@ -0,0 +1,34 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce
+
+// ZeroValueMode controls what a Debouncer with a zero duration does with
+// the functions passed to it, see WithZeroValueMode.
+type ZeroValueMode int
+
+const (
+	// ZeroImmediate executes the functions without delay, on a new
+	// goroutine, as a timer with a zero duration does.
+	ZeroImmediate ZeroValueMode = iota + 1
+
+	// ZeroDisabled ignores the functions until a duration is configured,
+	// e.g. using WithDynamicAfter, to catch accidental zero value use.
+	ZeroDisabled
+
+	// ZeroSync executes the functions without delay, right away on the
+	// calling goroutine, so a function must not need a lock held by the
+	// caller.
+	ZeroSync
+)
+
+// DefaultZeroValueMode is the ZeroValueMode used when none is set with
+// WithZeroValueMode. It must not be changed while Debouncers are in use.
+var DefaultZeroValueMode = ZeroImmediate
+
+// disabled reports whether calls must be ignored because no duration
+// is configured, see ZeroDisabled.
+// d.mu must be held.
+func (d *Debouncer) disabled() bool {
+	return d.after == 0 && d.cfg.dynamicAfter == nil && d.cfg.zeroValueMode == ZeroDisabled
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Bjørn Erik Pedersen
+// SPDX-License-Identifier: MIT
+
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bep/debounce"
+)
+
+func TestZeroValueImmediate(t *testing.T) {
+	var (
+		counter uint64
+		d       debounce.Debouncer
+	)
+
+	d.Do(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+
+	d.Close()
+}
+
+func TestZeroValueSync(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		counter uint64
+		d       debounce.Debouncer
+	)
+
+	d.SwapOptions(debounce.WithZeroValueMode(debounce.ZeroSync))
+
+	d.Do(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+
+	// Executed on the calling goroutine.
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+
+	// The default executes asynchronously, so the function may take a lock
+	// held by the caller.
+	debounced := debounce.New(0)
+	done := make(chan struct{})
+	mu.Lock()
+	debounced(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		close(done)
+	})
+	mu.Unlock()
+	<-done
+}
+
+func TestZeroValueDisabled(t *testing.T) {
+	var (
+		counter uint64
+		d       debounce.Debouncer
+	)
+
+	f := func() {
+		atomic.AddUint64(&counter, 1)
+	}
+
+	d.SwapOptions(debounce.WithZeroValueMode(debounce.ZeroDisabled))
+	d.Do(f)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 0 {
+		t.Fatal("Expected count 0, was", c)
+	}
+
+	// Configured.
+	d.SwapOptions(debounce.WithDynamicAfter(func(time.Time) time.Duration {
+		return 10 * time.Millisecond
+	}))
+	d.Do(f)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 1 {
+		t.Error("Expected count 1, was", c)
+	}
+}
+
+func TestDefaultZeroValueMode(t *testing.T) {
+	defer func(mode debounce.ZeroValueMode) {
+		debounce.DefaultZeroValueMode = mode
+	}(debounce.DefaultZeroValueMode)
+	debounce.DefaultZeroValueMode = debounce.ZeroDisabled
+
+	var counter uint64
+
+	debounced := debounce.New(0)
+	debounced(func() {
+		atomic.AddUint64(&counter, 1)
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	if c := atomic.LoadUint64(&counter); c != 0 {
+		t.Error("Expected count 0, was", c)
+	}
+}